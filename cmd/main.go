@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/app"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/config"
 	"log/slog"
@@ -26,15 +27,26 @@ func main() {
 	)
 
 	application := app.New(log, cfg)
+	if application == nil {
+		log.Error("failed to initialize application")
+		os.Exit(1)
+	}
 
 	go application.GRPCSrv.MustRun()
 	application.AMQPApp.SetupMessageConsumers()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	go application.OutboxRelay.Run(ctx)
+	go application.Retention.Run(ctx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
 	sign := <-stop
 	log.Info("stopping application", slog.String("signal", sign.String()))
+	cancel()
+	application.Stop()
+	log.Info("application stopped")
 }
 
 func setupLogger(env string) *slog.Logger {
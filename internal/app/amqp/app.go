@@ -14,7 +14,8 @@ type App struct {
 }
 
 type Amqp interface {
-	Consume(queue string, routingKey string, handler func(msg amqp091.Delivery) error) error
+	Consume(queue string, router *rabbitmq.Router) error
+	Close() error
 }
 
 type UserService interface {
@@ -32,19 +33,32 @@ func New(log *slog.Logger, service UserService, amqp Amqp) *App {
 }
 
 func (a *App) SetupMessageConsumers() {
-	a.consumeMessages("club", "user.club.activated", a.usrService.HandleCreateUser)
-	a.consumeMessages("club", "user.club.updated", a.usrService.HandleUpdateUser)
-	a.consumeMessages("club", "user.club.deleted", a.usrService.HandleDeleteUser)
+	router := rabbitmq.NewRouter()
+	router.Handle("user.club.activated", a.usrService.HandleCreateUser)
+	router.Handle("user.club.updated", a.usrService.HandleUpdateUser)
+	router.Handle("user.club.deleted", a.usrService.HandleDeleteUser)
+
+	a.consumeMessages("club", router)
 }
 
-func (a *App) consumeMessages(queue, routingKey string, handler rabbitmq.Handler) {
-	go func() {
-		const op = "amqp.app.consumeMessages"
-		log := a.log.With(slog.String("op", op))
+func (a *App) consumeMessages(queue string, router *rabbitmq.Router) {
+	const op = "amqp.app.consumeMessages"
+	log := a.log.With(slog.String("op", op))
+
+	err := a.amqp.Consume(queue, router)
+	if err != nil {
+		log.Error("failed to consume ", logger.Err(err))
+	}
+}
 
-		err := a.amqp.Consume(queue, routingKey, handler)
-		if err != nil {
-			log.Error("failed to consume ", logger.Err(err))
-		}
-	}()
+func (a *App) Stop() {
+	const op = "amqp.app.Stop"
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("stopping amqp consumers")
+
+	err := a.amqp.Close()
+	if err != nil {
+		log.Error("failed to close amqp connection", logger.Err(err))
+	}
 }
@@ -1,22 +1,31 @@
 package app
 
 import (
+	"crypto/rsa"
 	amqpapp "github.com/ARUMANDESU/uniclubs-club-service/internal/app/amqp"
 	grpcapp "github.com/ARUMANDESU/uniclubs-club-service/internal/app/grpc"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/auth"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/config"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/rabbitmq"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/info"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/management"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/membership"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/outbox"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/retention"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/role"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/user"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage/filesystem"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage/postgresql"
+	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
 	"log/slog"
 )
 
 type App struct {
-	GRPCSrv *grpcapp.App
-	AMQPApp *amqpapp.App
+	GRPCSrv     *grpcapp.App
+	AMQPApp     *amqpapp.App
+	OutboxRelay *outbox.Relay
+	Retention   *retention.Cleaner
 }
 
 func New(log *slog.Logger, cfg *config.Config) *App {
@@ -28,26 +37,74 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 		return nil
 	}
 
-	rmq, err := rabbitmq.New(cfg.Rabbitmq, log)
+	verifier, err := newVerifier(cfg.Auth)
+	if err != nil {
+		log.Error("failed to configure token verification", logger.Err(err))
+		return nil
+	}
+
+	fileStorage, err := filesystem.New(cfg.FileStorage.Dir, cfg.FileStorage.BaseURL)
+	if err != nil {
+		log.Error("failed to configure file storage", logger.Err(err))
+		return nil
+	}
+
+	roleTemplates, err := cfg.Club.RoleTemplates()
 	if err != nil {
+		log.Error("failed to configure club role templates", logger.Err(err))
+		return nil
+	}
 
+	rmq, err := rabbitmq.New(cfg.Rabbitmq, log)
+	if err != nil {
+		log.Error("failed to connect to rabbitmq", logger.Err(err))
+		return nil
 	}
 
 	usrService := user.New(log, storage)
-	managementService := management.New(log, storage)
-	membershipService := membership.New(log, storage)
-	infoService := info.New(log, storage)
 	permissionService := accessControl.New(log, storage)
+	managementService := management.New(log, storage, fileStorage, permissionService, roleTemplates)
+	membershipService := membership.New(log, storage, permissionService)
+	infoService := info.New(log, storage)
+	roleService := role.New(log, storage, permissionService)
 
 	grpcApp := grpcapp.New(
 		log,
 		cfg.GRPC.Port,
+		verifier,
 		managementService,
 		membershipService,
 		infoService,
 		permissionService,
+		roleService,
 	)
+	rmq.NotifyStatus(grpcApp.SetServing)
 	amqpApp := amqpapp.New(log, usrService, rmq)
+	outboxRelay := outbox.New(log, storage, rmq, cfg.Outbox.Interval, cfg.Outbox.BatchSize)
+	cleaner := retention.New(
+		log, storage, cfg.Retention.ProcessedMessages, cfg.Retention.Interval, cfg.Retention.BatchSize,
+	)
+
+	return &App{GRPCSrv: grpcApp, AMQPApp: amqpApp, OutboxRelay: outboxRelay, Retention: cleaner}
+}
+
+func newVerifier(cfg config.Auth) (*auth.Verifier, error) {
+	var (
+		publicKey *rsa.PublicKey
+		err       error
+	)
+	if cfg.RSAPublicKeyPath != "" {
+		publicKey, err = auth.LoadRSAPublicKey(cfg.RSAPublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewVerifier([]byte(cfg.HMACSecret), publicKey, cfg.Issuer)
+}
 
-	return &App{GRPCSrv: grpcApp, AMQPApp: amqpApp}
+// Stop shuts down the gRPC server first so that no new requests arrive, then the amqp connection.
+func (a *App) Stop() {
+	a.GRPCSrv.Stop()
+	a.AMQPApp.Stop()
 }
@@ -2,8 +2,11 @@ package grpcapp
 
 import (
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/auth"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/grpc/club"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"log/slog"
 	"net"
 )
@@ -11,18 +14,21 @@ import (
 type App struct {
 	log        *slog.Logger
 	gRPCServer *grpc.Server
+	health     *health.Server
 	port       int
 }
 
 func New(
 	log *slog.Logger,
 	port int,
+	verifier *auth.Verifier,
 	managementService club.ManagementService,
 	membershipService club.MembershipService,
 	infoService club.InfoService,
 	permission club.PermissionService,
+	roleService club.RoleService,
 ) *App {
-	gRPCServer := grpc.NewServer()
+	gRPCServer := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(verifier)))
 
 	club.Register(
 		gRPCServer,
@@ -30,11 +36,16 @@ func New(
 		membershipService,
 		infoService,
 		permission,
+		roleService,
 	)
 
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+
 	return &App{
 		log:        log,
 		gRPCServer: gRPCServer,
+		health:     healthServer,
 		port:       port,
 	}
 }
@@ -72,5 +83,16 @@ func (a *App) Stop() {
 
 	a.log.With(slog.String("op", op)).Info("stopping gRPC Server")
 
+	a.health.Shutdown()
 	a.gRPCServer.GracefulStop()
 }
+
+// SetServing sets the status reported by the gRPC health service.
+func (a *App) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	a.health.SetServingStatus("", status)
+}
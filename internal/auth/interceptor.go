@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// publicServices are served without authentication.
+var publicServices = []string{"/grpc.health.v1.Health/"}
+
+// UnaryServerInterceptor authenticates requests with the bearer token of the authorization metadata
+// and puts the Identity of the caller into the request context.
+func UnaryServerInterceptor(verifier *Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for _, service := range publicServices {
+			if strings.HasPrefix(info.FullMethod, service) {
+				return handler(ctx, req)
+			}
+		}
+
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			if errors.Is(err, ErrTokenExpired) {
+				return nil, status.Error(codes.Unauthenticated, ErrTokenExpired.Error())
+			}
+			return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+		}
+
+		ctx = WithIdentity(ctx, Identity{UserID: claims.UserID, Roles: claims.Roles})
+
+		return handler(ctx, req)
+	}
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
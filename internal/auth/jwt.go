@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token is expired")
+	ErrNoKeys       = errors.New("no token verification key configured")
+)
+
+// Claims are the JWT claims issued by the user service.
+type Claims struct {
+	UserID    int64    `json:"user_id"`
+	Roles     []string `json:"roles"`
+	Issuer    string   `json:"iss"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+}
+
+// Verifier validates HS256 and RS256 signed JWTs, an algorithm is accepted only if its key is configured.
+type Verifier struct {
+	secret    []byte
+	publicKey *rsa.PublicKey
+	issuer    string
+	now       func() time.Time
+}
+
+func NewVerifier(secret []byte, publicKey *rsa.PublicKey, issuer string) (*Verifier, error) {
+	const op = "auth.NewVerifier"
+
+	if len(secret) == 0 && publicKey == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoKeys)
+	}
+
+	return &Verifier{
+		secret:    secret,
+		publicKey: publicKey,
+		issuer:    issuer,
+		now:       time.Now,
+	}, nil
+}
+
+// LoadRSAPublicKey reads a PEM encoded PKIX or PKCS #1 RSA public key.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	const op = "auth.LoadRSAPublicKey"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", op)
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: key is not an RSA public key", op)
+	}
+
+	return rsaKey, nil
+}
+
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+
+	if err = v.verifySignature(header.Alg, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	now := v.now().Unix()
+	switch {
+	case claims.ExpiresAt == 0:
+		return nil, fmt.Errorf("%w: exp is missing", ErrInvalidToken)
+	case now >= claims.ExpiresAt:
+		return nil, ErrTokenExpired
+	case claims.NotBefore != 0 && now < claims.NotBefore:
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	case claims.UserID <= 0:
+		return nil, fmt.Errorf("%w: user_id is missing", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) verifySignature(alg, signingInput string, signature []byte) error {
+	switch {
+	case alg == "HS256" && len(v.secret) != 0:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+	case alg == "RS256" && v.publicKey != nil:
+		hash := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, hash[:], signature); err != nil {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
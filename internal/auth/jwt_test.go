@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func signHS256(t *testing.T, secret []byte, alg string, claims any) string {
+	t.Helper()
+	input := encodeSegment(t, map[string]string{"alg": alg, "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims any) string {
+	t.Helper()
+	input := encodeSegment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	hash := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	secret := []byte("secret")
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := Claims{UserID: 42, Roles: []string{"staff"}, Issuer: "uniclubs", ExpiresAt: testNow.Add(time.Hour).Unix()}
+	expired := valid
+	expired.ExpiresAt = testNow.Add(-time.Minute).Unix()
+	noExpiry := valid
+	noExpiry.ExpiresAt = 0
+	otherIssuer := valid
+	otherIssuer.Issuer = "other"
+	noUser := valid
+	noUser.UserID = 0
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"Valid HS256", signHS256(t, secret, "HS256", valid), nil},
+		{"Valid RS256", signRS256(t, key, valid), nil},
+		{"Wrong secret", signHS256(t, []byte("other"), "HS256", valid), ErrInvalidToken},
+		{"Algorithm none", signHS256(t, secret, "none", valid), ErrInvalidToken},
+		{"Expired", signHS256(t, secret, "HS256", expired), ErrTokenExpired},
+		{"Missing exp", signHS256(t, secret, "HS256", noExpiry), ErrInvalidToken},
+		{"Unexpected issuer", signHS256(t, secret, "HS256", otherIssuer), ErrInvalidToken},
+		{"Missing user", signHS256(t, secret, "HS256", noUser), ErrInvalidToken},
+		{"Malformed", "not-a-token", ErrInvalidToken},
+	}
+
+	verifier, err := NewVerifier(secret, &key.PublicKey, "uniclubs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && claims.UserID != valid.UserID {
+				t.Errorf("Verify() user_id = %d, want %d", claims.UserID, valid.UserID)
+			}
+		})
+	}
+}
@@ -3,16 +3,22 @@ package config
 import (
 	"flag"
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
 	"github.com/ilyakaznacheev/cleanenv"
 	"os"
 	"time"
 )
 
 type Config struct {
-	Env         string   `yaml:"env" env:"ENV" env-default:"local"`
-	GRPC        GRPC     `yaml:"grpc"`
-	Rabbitmq    Rabbitmq `yaml:"rabbitmq"`
-	DatabaseDSN string   `yaml:"database_dsn" env:"DATABASE_DSN" env-required:"true"`
+	Env         string      `yaml:"env" env:"ENV" env-default:"local"`
+	GRPC        GRPC        `yaml:"grpc"`
+	Auth        Auth        `yaml:"auth"`
+	Rabbitmq    Rabbitmq    `yaml:"rabbitmq"`
+	FileStorage FileStorage `yaml:"file_storage"`
+	Club        Club        `yaml:"club"`
+	Outbox      Outbox      `yaml:"outbox"`
+	Retention   Retention   `yaml:"retention"`
+	DatabaseDSN string      `yaml:"database_dsn" env:"DATABASE_DSN" env-required:"true"`
 }
 
 type GRPC struct {
@@ -20,6 +26,16 @@ type GRPC struct {
 	Timeout time.Duration `yaml:"timeout" env:"GRPC_TIMEOUT"`
 }
 
+// Auth configures verification of access tokens, at least one key is required.
+type Auth struct {
+	// HMACSecret verifies HS256 tokens.
+	HMACSecret string `yaml:"hmac_secret" env:"AUTH_HMAC_SECRET"`
+	// RSAPublicKeyPath is a PEM file with the public key verifying RS256 tokens.
+	RSAPublicKeyPath string `yaml:"rsa_public_key_path" env:"AUTH_RSA_PUBLIC_KEY_PATH"`
+	// Issuer is the expected iss claim, it is not checked if empty.
+	Issuer string `yaml:"issuer" env:"AUTH_ISSUER"`
+}
+
 type Rabbitmq struct {
 	User         string `yaml:"user" env:"RABBITMQ_USER"`
 	Password     string `yaml:"password" env:"RABBITMQ_PASSWORD"`
@@ -27,6 +43,79 @@ type Rabbitmq struct {
 	Port         string `yaml:"port" env:"RABBITMQ_PORT"`
 	ExchangeName string `yaml:"exchange_name" env:"RABBITMQ_EXCHANGE_NAME"`
 	UserQueue    string `yaml:"user_queue" env:"RABBITMQ_USER_QUEUE"`
+	// DeadLetterExchange receives messages that failed permanently or ran out of retries.
+	DeadLetterExchange string `yaml:"dead_letter_exchange" env:"RABBITMQ_DEAD_LETTER_EXCHANGE" env-default:"club.dlx"`
+	Retry              Retry  `yaml:"retry"`
+}
+
+type Retry struct {
+	MaxAttempts int           `yaml:"max_attempts" env:"RABBITMQ_RETRY_MAX_ATTEMPTS" env-default:"5"`
+	BaseDelay   time.Duration `yaml:"base_delay" env:"RABBITMQ_RETRY_BASE_DELAY" env-default:"1s"`
+	MaxDelay    time.Duration `yaml:"max_delay" env:"RABBITMQ_RETRY_MAX_DELAY" env-default:"5m"`
+}
+
+type FileStorage struct {
+	Dir     string `yaml:"dir" env:"FILE_STORAGE_DIR" env-default:"./uploads"`
+	BaseURL string `yaml:"base_url" env:"FILE_STORAGE_BASE_URL" env-default:"http://localhost:8080/uploads"`
+}
+
+type Outbox struct {
+	Interval  time.Duration `yaml:"interval" env:"OUTBOX_INTERVAL" env-default:"1s"`
+	BatchSize int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+}
+
+// Retention bounds how long processed message IDs are kept for deduplication, it must exceed the longest
+// time a message can be redelivered.
+type Retention struct {
+	ProcessedMessages time.Duration `yaml:"processed_messages" env:"PROCESSED_MESSAGES_RETENTION" env-default:"168h"`
+	Interval          time.Duration `yaml:"interval" env:"RETENTION_INTERVAL" env-default:"1h"`
+	BatchSize         int           `yaml:"batch_size" env:"RETENTION_BATCH_SIZE" env-default:"1000"`
+}
+
+type Club struct {
+	// DefaultRoles are created for every approved club, the first role has the highest position.
+	DefaultRoles []DefaultRole `yaml:"default_roles"`
+}
+
+type DefaultRole struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions"`
+	Color       int      `yaml:"color"`
+	Member      bool     `yaml:"member"`
+	Owner       bool     `yaml:"owner"`
+}
+
+// RoleTemplates converts DefaultRoles to domain.RoleTemplate,
+// domain.DefaultRoleTemplates are returned if none are configured.
+func (c Club) RoleTemplates() ([]domain.RoleTemplate, error) {
+	const op = "config.Club.RoleTemplates"
+
+	if len(c.DefaultRoles) == 0 {
+		return domain.DefaultRoleTemplates, nil
+	}
+
+	templates := make([]domain.RoleTemplate, len(c.DefaultRoles))
+	for i, r := range c.DefaultRoles {
+		permissions := domain.Permissions{PermissionsArr: r.Permissions}
+		if err := permissions.StringArrToHex(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		templates[i] = domain.RoleTemplate{
+			Name:        r.Name,
+			Permissions: permissions.PermissionsHex,
+			Position:    len(c.DefaultRoles) - 1 - i,
+			Color:       r.Color,
+			IsDefault:   r.Member,
+			ForOwner:    r.Owner,
+		}
+	}
+
+	if err := domain.ValidateRoleTemplates(templates); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
 }
 
 func MustLoad() *Config {
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type ApplicationStatus string
+
+const (
+	ApplicationStatusPending  ApplicationStatus = "pending"
+	ApplicationStatusApproved ApplicationStatus = "approved"
+	ApplicationStatusRejected ApplicationStatus = "rejected"
+)
+
+// ClubApplication is a request to create a club and the staff decision on it.
+// A rejected club can be resubmitted, every submission is a separate application.
+type ClubApplication struct {
+	ID          int64
+	ClubID      int64
+	ClubName    string
+	UserID      int64
+	Status      ApplicationStatus
+	ReviewerID  int64
+	Reason      string
+	RequestedAt time.Time
+	DecidedAt   *time.Time
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditClubCreated          AuditAction = "club.created"
+	AuditClubApproved         AuditAction = "club.approved"
+	AuditClubRejected         AuditAction = "club.rejected"
+	AuditClubResubmitted      AuditAction = "club.resubmitted"
+	AuditClubUpdated          AuditAction = "club.updated"
+	AuditClubDeactivated      AuditAction = "club.deactivated"
+	AuditClubReactivated      AuditAction = "club.reactivated"
+	AuditClubImageUpdated     AuditAction = "club.image_updated"
+	AuditJoinPolicyChanged    AuditAction = "club.join_policy_changed"
+	AuditMaxMembersChanged    AuditAction = "club.max_members_changed"
+	AuditOwnershipTransferred AuditAction = "club.ownership_transferred"
+	AuditJoinRequested        AuditAction = "membership.requested"
+	AuditMembershipApproved   AuditAction = "membership.approved"
+	AuditMembershipRejected   AuditAction = "membership.rejected"
+	AuditMembershipWaitlisted AuditAction = "membership.waitlisted"
+	AuditJoinRequestWithdrawn AuditAction = "membership.withdrawn"
+	AuditInvitationCreated    AuditAction = "invitation.created"
+	AuditInvitationRevoked    AuditAction = "invitation.revoked"
+	AuditInvitationRedeemed   AuditAction = "invitation.redeemed"
+	AuditMemberLeft           AuditAction = "member.left"
+	AuditMemberKicked         AuditAction = "member.kicked"
+	AuditMemberBanned         AuditAction = "member.banned"
+	AuditMemberUnbanned       AuditAction = "member.unbanned"
+	AuditRoleCreated          AuditAction = "role.created"
+	AuditRoleUpdated          AuditAction = "role.updated"
+	AuditRoleMoved            AuditAction = "role.moved"
+	AuditRoleDeleted          AuditAction = "role.deleted"
+	AuditRoleAssigned         AuditAction = "role.assigned"
+	AuditRoleRevoked          AuditAction = "role.revoked"
+)
+
+// AuditEntry is a single record of the append-only audit log.
+// ActorID and TargetID are zero when unknown or not applicable.
+// Before and After are stored as JSON, entries read from the log hold them as json.RawMessage.
+type AuditEntry struct {
+	ID        int64
+	ClubID    int64
+	ActorID   int64
+	TargetID  int64
+	Action    AuditAction
+	Before    any
+	After     any
+	CreatedAt time.Time
+}
+
+// AuditFilter narrows the audit log of ClubID, zero ActorID and empty Action match any.
+type AuditFilter struct {
+	ClubID  int64
+	ActorID int64
+	Action  AuditAction
+}
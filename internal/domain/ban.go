@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type Ban struct {
+	ClubID    int64
+	UserID    int64
+	BannedBy  int64
+	Reason    string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	User      User
+}
+
+// IsActive reports whether the ban is still in effect at t.
+func (b Ban) IsActive(t time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(t)
+}
@@ -6,19 +6,144 @@ import (
 	"time"
 )
 
+type ClubStatus string
+
+const (
+	ClubStatusPending     ClubStatus = "pending"
+	ClubStatusActive      ClubStatus = "active"
+	ClubStatusDeactivated ClubStatus = "deactivated"
+	ClubStatusRejected    ClubStatus = "rejected"
+)
+
+// clubStatusTransitions lists the statuses a club can move to from each status.
+var clubStatusTransitions = map[ClubStatus][]ClubStatus{
+	ClubStatusPending:     {ClubStatusActive, ClubStatusRejected},
+	ClubStatusActive:      {ClubStatusDeactivated},
+	ClubStatusDeactivated: {ClubStatusActive},
+	ClubStatusRejected:    {ClubStatusPending},
+}
+
+// CanTransitionTo reports whether a club in status s is allowed to move to next.
+func (s ClubStatus) CanTransitionTo(next ClubStatus) bool {
+	for _, status := range clubStatusTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Abandoned returns the status a club in status s moves to when its owner is deleted
+// and no member can take over: active clubs are deactivated, pending applications are rejected.
+func (s ClubStatus) Abandoned() ClubStatus {
+	switch s {
+	case ClubStatusActive:
+		return ClubStatusDeactivated
+	case ClubStatusPending:
+		return ClubStatusRejected
+	default:
+		return s
+	}
+}
+
+// SuccessorCandidate is a member who can take over a club whose owner is deleted.
+type SuccessorCandidate struct {
+	UserID int64
+	// Position is the highest role position of the member, -1 if the member has no roles.
+	Position int
+	JoinedAt time.Time
+}
+
+// PickSuccessor returns the candidate with the highest role, the longest-standing one among equals.
+// It returns false if there are no candidates, the club is then abandoned.
+func PickSuccessor(candidates []SuccessorCandidate) (int64, bool) {
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		switch {
+		case c.Position != best.Position:
+			if c.Position > best.Position {
+				best = c
+			}
+		case !c.JoinedAt.Equal(best.JoinedAt):
+			if c.JoinedAt.Before(best.JoinedAt) {
+				best = c
+			}
+		case c.UserID < best.UserID:
+			best = c
+		}
+	}
+	return best.UserID, true
+}
+
+// JoinPolicy decides how users become members of a club.
+type JoinPolicy string
+
+const (
+	// JoinPolicyOpen makes a join request a membership right away.
+	JoinPolicyOpen JoinPolicy = "open"
+	// JoinPolicyApproval requires a member with permission to approve the join request.
+	JoinPolicyApproval JoinPolicy = "approval"
+	// JoinPolicyInviteOnly refuses join requests, users join through invitations.
+	JoinPolicyInviteOnly JoinPolicy = "invite_only"
+	// JoinPolicyClosed refuses new members.
+	JoinPolicyClosed JoinPolicy = "closed"
+)
+
+// IsValid reports whether p is one of the known join policies.
+func (p JoinPolicy) IsValid() bool {
+	switch p {
+	case JoinPolicyOpen, JoinPolicyApproval, JoinPolicyInviteOnly, JoinPolicyClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// AcceptsJoinRequests reports whether users can request to join a club with the policy.
+func (p JoinPolicy) AcceptsJoinRequests() bool {
+	return p == JoinPolicyOpen || p == JoinPolicyApproval
+}
+
+// ApprovesJoinRequests reports whether join requests to a club with the policy are approved without a reviewer.
+func (p JoinPolicy) ApprovesJoinRequests() bool {
+	return p == JoinPolicyOpen
+}
+
+// QueuedStatuses returns the statuses of join requests that are admitted to free seats of a club with the policy.
+// Waitlisted requests were approved already, pending ones only queue where they would be approved without a reviewer.
+func (p JoinPolicy) QueuedStatuses() []JoinRequestStatus {
+	if p.ApprovesJoinRequests() {
+		return []JoinRequestStatus{JoinRequestStatusWaitlisted, JoinRequestStatusPending}
+	}
+	return []JoinRequestStatus{JoinRequestStatusWaitlisted}
+}
+
 type Club struct {
-	ID           int64
-	Name         string
-	OwnerID      int64
-	Description  string
-	ClubType     string
-	LogoURL      string
-	BannerURL    string
+	ID          int64
+	Name        string
+	OwnerID     int64
+	Description string
+	ClubType    string
+	LogoURL     string
+	BannerURL   string
+	Status      ClubStatus
+	JoinPolicy  JoinPolicy
+	// MaxMembers is zero if the number of members is not limited.
+	MaxMembers   int
 	NumOFMembers int64
 	CreatedAt    time.Time
 	Roles        []Role
 }
 
+// IsFull reports whether a club with the given number of members has no free seats.
+func (c Club) IsFull(members int) bool {
+	return c.MaxMembers > 0 && members >= c.MaxMembers
+}
+
 func (c Club) ToClubObject() *clubv1.ClubObject {
 	roles := make([]*clubv1.Role, len(c.Roles))
 	for i, role := range c.Roles {
@@ -26,6 +151,8 @@ func (c Club) ToClubObject() *clubv1.ClubObject {
 		roles[i] = &clubv1.Role{
 			Name:        role.Name,
 			Permissions: role.Permissions.PermissionsArr,
+			Position:    int32(role.Position),
+			Color:       int32(role.Color),
 		}
 	}
 
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClubStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from ClubStatus
+		to   ClubStatus
+		want bool
+	}{
+		{"Approve pending", ClubStatusPending, ClubStatusActive, true},
+		{"Reject pending", ClubStatusPending, ClubStatusRejected, true},
+		{"Deactivate active", ClubStatusActive, ClubStatusDeactivated, true},
+		{"Reactivate deactivated", ClubStatusDeactivated, ClubStatusActive, true},
+		{"Deactivate pending", ClubStatusPending, ClubStatusDeactivated, false},
+		{"Reject active", ClubStatusActive, ClubStatusRejected, false},
+		{"Approve rejected", ClubStatusRejected, ClubStatusActive, false},
+		{"Resubmit rejected", ClubStatusRejected, ClubStatusPending, true},
+		{"Resubmit active", ClubStatusActive, ClubStatusPending, false},
+		{"Deactivate deactivated", ClubStatusDeactivated, ClubStatusDeactivated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClubStatus_Abandoned(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ClubStatus
+		want   ClubStatus
+	}{
+		{"Active club is deactivated", ClubStatusActive, ClubStatusDeactivated},
+		{"Pending club is rejected", ClubStatusPending, ClubStatusRejected},
+		{"Deactivated club stays deactivated", ClubStatusDeactivated, ClubStatusDeactivated},
+		{"Rejected club stays rejected", ClubStatusRejected, ClubStatusRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Abandoned(); got != tt.want {
+				t.Errorf("Abandoned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickSuccessor(t *testing.T) {
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		candidates []SuccessorCandidate
+		want       int64
+		wantOK     bool
+	}{
+		{"No members left", nil, 0, false},
+		{"Single member", []SuccessorCandidate{{UserID: 2, Position: -1, JoinedAt: late}}, 2, true},
+		{"Highest role wins over seniority", []SuccessorCandidate{
+			{UserID: 2, Position: 0, JoinedAt: early},
+			{UserID: 3, Position: 2, JoinedAt: late},
+			{UserID: 4, Position: 1, JoinedAt: early},
+		}, 3, true},
+		{"Member with role wins over member without", []SuccessorCandidate{
+			{UserID: 2, Position: -1, JoinedAt: early},
+			{UserID: 3, Position: 0, JoinedAt: late},
+		}, 3, true},
+		{"Longest-standing member among equal roles", []SuccessorCandidate{
+			{UserID: 2, Position: 1, JoinedAt: late},
+			{UserID: 3, Position: 1, JoinedAt: early},
+		}, 3, true},
+		{"Lowest id among equals", []SuccessorCandidate{
+			{UserID: 5, Position: 1, JoinedAt: early},
+			{UserID: 4, Position: 1, JoinedAt: early},
+		}, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PickSuccessor(tt.candidates)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PickSuccessor() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestJoinPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       JoinPolicy
+		wantValid    bool
+		wantAccepts  bool
+		wantApproves bool
+	}{
+		{"Open", JoinPolicyOpen, true, true, true},
+		{"Approval", JoinPolicyApproval, true, true, false},
+		{"Invite only", JoinPolicyInviteOnly, true, false, false},
+		{"Closed", JoinPolicyClosed, true, false, false},
+		{"Unknown", JoinPolicy("lottery"), false, false, false},
+		{"Empty", JoinPolicy(""), false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsValid(); got != tt.wantValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.wantValid)
+			}
+			if got := tt.policy.AcceptsJoinRequests(); got != tt.wantAccepts {
+				t.Errorf("AcceptsJoinRequests() = %v, want %v", got, tt.wantAccepts)
+			}
+			if got := tt.policy.ApprovesJoinRequests(); got != tt.wantApproves {
+				t.Errorf("ApprovesJoinRequests() = %v, want %v", got, tt.wantApproves)
+			}
+		})
+	}
+}
+
+func TestJoinPolicy_QueuedStatuses(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy JoinPolicy
+		want   []JoinRequestStatus
+	}{
+		{"Open club admits pending requests", JoinPolicyOpen, []JoinRequestStatus{JoinRequestStatusWaitlisted, JoinRequestStatusPending}},
+		{"Approval club keeps pending requests for review", JoinPolicyApproval, []JoinRequestStatus{JoinRequestStatusWaitlisted}},
+		{"Invite only", JoinPolicyInviteOnly, []JoinRequestStatus{JoinRequestStatusWaitlisted}},
+		{"Closed", JoinPolicyClosed, []JoinRequestStatus{JoinRequestStatusWaitlisted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.QueuedStatuses(); !slices.Equal(got, tt.want) {
+				t.Errorf("QueuedStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClub_IsFull(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxMembers int
+		members    int
+		want       bool
+	}{
+		{"Unlimited", 0, 1000, false},
+		{"Free seats", 10, 9, false},
+		{"Last seat taken", 10, 10, true},
+		{"Over the limit after lowering it", 10, 12, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Club{MaxMembers: tt.maxMembers}).IsFull(tt.members); got != tt.want {
+				t.Errorf("IsFull() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package dtos
 
-import clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+import (
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+)
 
 type CreateClubDTO struct {
-	Name        string
-	Description string
-	ClubType    string
-	OwnerID     int64
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ClubType    string `json:"club_type"`
+	OwnerID     int64  `json:"owner_id"`
 }
 
 func CreateClubRequestToDTO(req *clubv1.CreateClubRequest) CreateClubDTO {
@@ -17,3 +19,52 @@ func CreateClubRequestToDTO(req *clubv1.CreateClubRequest) CreateClubDTO {
 		OwnerID:     req.OwnerId,
 	}
 }
+
+// UpdateClubDTO holds a partial club update, nil fields are left untouched.
+type UpdateClubDTO struct {
+	ClubID      int64
+	UserID      int64
+	Name        *string
+	Description *string
+	ClubType    *string
+}
+
+// UpdateClubRequestToDTO converts request to UpdateClubDTO.
+// If update_mask is set only the listed paths are applied, otherwise every non-empty field is applied.
+func UpdateClubRequestToDTO(req *clubv1.UpdateClubRequest) UpdateClubDTO {
+	dto := UpdateClubDTO{
+		ClubID: req.GetClubId(),
+		UserID: req.GetUserId(),
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if req.GetName() != "" {
+			paths = append(paths, "name")
+		}
+		if req.GetDescription() != "" {
+			paths = append(paths, "description")
+		}
+		if req.GetClubType() != "" {
+			paths = append(paths, "club_type")
+		}
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			dto.Name = &req.Name
+		case "description":
+			dto.Description = &req.Description
+		case "club_type":
+			dto.ClubType = &req.ClubType
+		}
+	}
+
+	return dto
+}
+
+// IsEmpty reports whether dto does not change any field.
+func (dto UpdateClubDTO) IsEmpty() bool {
+	return dto.Name == nil && dto.Description == nil && dto.ClubType == nil
+}
@@ -0,0 +1,25 @@
+package dtos
+
+type CreateRoleDTO struct {
+	ClubID      int64
+	ActorID     int64
+	Name        string
+	Permissions uint64
+	Position    int
+	Color       int
+}
+
+// UpdateRoleDTO holds a partial role update, nil fields are left untouched.
+type UpdateRoleDTO struct {
+	ClubID      int64
+	RoleID      int
+	ActorID     int64
+	Name        *string
+	Permissions *uint64
+	Color       *int
+}
+
+// IsEmpty reports whether dto does not change any field.
+func (dto UpdateRoleDTO) IsEmpty() bool {
+	return dto.Name == nil && dto.Permissions == nil && dto.Color == nil
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Routing keys of events published by club service.
+const (
+	EventClubCreated     = "club.created"
+	EventClubApproved    = "club.approved"
+	EventClubRejected    = "club.rejected"
+	EventClubResubmitted = "club.resubmitted"
+	EventMemberJoined    = "club.member.joined"
+	EventMemberLeft      = "club.member.left"
+	EventMemberKicked    = "club.member.kicked"
+	EventMemberBanned    = "club.member.banned"
+	EventMemberInvited   = "club.member.invited"
+	EventUserRemoved     = "club.user.removed"
+	// EventUserResyncRequested asks the user service to resend the current state of the user.
+	EventUserResyncRequested = "club.user.resync_requested"
+)
+
+// Event is a domain event to be published with RoutingKey, Payload is encoded as JSON.
+type Event struct {
+	RoutingKey string
+	Payload    any
+}
+
+type ClubEvent struct {
+	ClubID     int64     `json:"club_id"`
+	OwnerID    int64     `json:"owner_id"`
+	ReviewerID int64     `json:"reviewer_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	ClubType   string    `json:"club_type,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type MemberEvent struct {
+	ClubID     int64     `json:"club_id"`
+	UserID     int64     `json:"user_id"`
+	ActorID    int64     `json:"actor_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// InvitationEvent is emitted when a user is invited to the club directly, Code is used to accept it.
+type InvitationEvent struct {
+	ClubID       int64      `json:"club_id"`
+	InvitationID int64      `json:"invitation_id"`
+	InviteeID    int64      `json:"invitee_id"`
+	ActorID      int64      `json:"actor_id"`
+	Code         string     `json:"code"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// UserRemovedEvent is emitted when a deleted user is removed from clubs. Clubs without members
+// to take over are left without owner in ClosedClubs, they are deactivated, or rejected if pending.
+type UserRemovedEvent struct {
+	UserID           int64               `json:"user_id"`
+	TransferredClubs []OwnershipHandover `json:"transferred_clubs"`
+	ClosedClubs      []int64             `json:"closed_clubs"`
+	OccurredAt       time.Time           `json:"occurred_at"`
+}
+
+type OwnershipHandover struct {
+	ClubID     int64 `json:"club_id"`
+	NewOwnerID int64 `json:"new_owner_id"`
+}
+
+// UserResyncEvent is emitted when the email or barcode of ConflictingUserID is held by UserID,
+// so the user service resends UserID to replace the stale values.
+type UserResyncEvent struct {
+	UserID            int64     `json:"user_id"`
+	ConflictingUserID int64     `json:"conflicting_user_id"`
+	OccurredAt        time.Time `json:"occurred_at"`
+}
+
+// MessageMeta identifies a consumed event. Empty ID disables de-duplication and
+// zero Version disables the ordering check.
+type MessageMeta struct {
+	ID      string
+	Version int64
+}
+
+// OutboxMessage is an event stored in the outbox table waiting to be published.
+type OutboxMessage struct {
+	ID         int64
+	RoutingKey string
+	Payload    json.RawMessage
+	CreatedAt  time.Time
+}
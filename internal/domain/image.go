@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+var (
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrImageTooLarge        = errors.New("image is too large")
+	ErrInvalidImageSize     = errors.New("image dimensions are out of allowed range")
+)
+
+// imageExtensions maps supported content types to file extensions.
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+type ImageKind string
+
+const (
+	ImageKindLogo   ImageKind = "logo"
+	ImageKindBanner ImageKind = "banner"
+)
+
+type ImageSpec struct {
+	MaxBytes  int
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+}
+
+var ImageSpecs = map[ImageKind]ImageSpec{
+	ImageKindLogo: {
+		MaxBytes: 2 << 20,
+		MinWidth: 64, MinHeight: 64,
+		MaxWidth: 2048, MaxHeight: 2048,
+	},
+	ImageKindBanner: {
+		MaxBytes: 5 << 20,
+		MinWidth: 600, MinHeight: 150,
+		MaxWidth: 4096, MaxHeight: 2048,
+	},
+}
+
+type Image struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Extension returns file extension that matches the image content type.
+func (i Image) Extension() string {
+	return imageExtensions[i.ContentType]
+}
+
+// Validate checks that data is a supported image which fits the spec.
+func (s ImageSpec) Validate(data []byte) (*Image, error) {
+	const op = "domain.image.Validate"
+
+	if len(data) > s.MaxBytes {
+		return nil, fmt.Errorf("%s: %w", op, ErrImageTooLarge)
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := imageExtensions[contentType]; !ok {
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnsupportedImageType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", op, ErrUnsupportedImageType, err)
+	}
+
+	if cfg.Width < s.MinWidth || cfg.Height < s.MinHeight || cfg.Width > s.MaxWidth || cfg.Height > s.MaxHeight {
+		return nil, fmt.Errorf("%s: %w: %dx%d", op, ErrInvalidImageSize, cfg.Width, cfg.Height)
+	}
+
+	return &Image{
+		Data:        data,
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}, nil
+}
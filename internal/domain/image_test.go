@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImageSpec_Validate(t *testing.T) {
+	spec := ImageSpec{MaxBytes: 1 << 20, MinWidth: 10, MinHeight: 10, MaxWidth: 100, MaxHeight: 100}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"Valid png", encodePNG(t, 50, 50), nil},
+		{"Too small", encodePNG(t, 5, 50), ErrInvalidImageSize},
+		{"Too big", encodePNG(t, 50, 101), ErrInvalidImageSize},
+		{"Not an image", []byte("hello world"), ErrUnsupportedImageType},
+		{"Too many bytes", make([]byte, 2<<20), ErrImageTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := spec.Validate(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && img.Extension() != ".png" {
+				t.Errorf("Extension() = %v, want .png", img.Extension())
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Invitation lets users join the club by its code. A direct invitation has InviteeID set and can
+// be redeemed once by that user only, a shareable one can be redeemed by anyone up to MaxUses times.
+type Invitation struct {
+	ID        int64
+	ClubID    int64
+	Code      string
+	InviteeID int64
+	CreatedBy int64
+	// MaxUses is zero if the invitation can be redeemed any number of times.
+	MaxUses   int
+	Uses      int
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsDirect reports whether the invitation is addressed to a single user.
+func (i Invitation) IsDirect() bool {
+	return i.InviteeID != 0
+}
+
+// CanBeRedeemedBy reports whether userID can redeem the invitation at t.
+func (i Invitation) CanBeRedeemedBy(userID int64, t time.Time) bool {
+	switch {
+	case i.RevokedAt != nil:
+		return false
+	case i.ExpiresAt != nil && !i.ExpiresAt.After(t):
+		return false
+	case i.MaxUses > 0 && i.Uses >= i.MaxUses:
+		return false
+	case i.IsDirect() && i.InviteeID != userID:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvitation_CanBeRedeemedBy(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		invitation Invitation
+		userID     int64
+		want       bool
+	}{
+		{"Shareable", Invitation{}, 1, true},
+		{"Not expired", Invitation{ExpiresAt: &future}, 1, true},
+		{"Expired", Invitation{ExpiresAt: &past}, 1, false},
+		{"Expires now", Invitation{ExpiresAt: &now}, 1, false},
+		{"Revoked", Invitation{RevokedAt: &past}, 1, false},
+		{"Uses left", Invitation{MaxUses: 2, Uses: 1}, 1, true},
+		{"Uses exhausted", Invitation{MaxUses: 2, Uses: 2}, 1, false},
+		{"Direct to user", Invitation{InviteeID: 1, MaxUses: 1}, 1, true},
+		{"Direct to another user", Invitation{InviteeID: 2, MaxUses: 1}, 1, false},
+		{"Direct already redeemed", Invitation{InviteeID: 1, MaxUses: 1, Uses: 1}, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invitation.CanBeRedeemedBy(tt.userID, now); got != tt.want {
+				t.Errorf("CanBeRedeemedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
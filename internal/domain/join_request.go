@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestStatusPending JoinRequestStatus = "pending"
+	// JoinRequestStatusWaitlisted is an approved request of a full club waiting for a free seat.
+	JoinRequestStatusWaitlisted JoinRequestStatus = "waitlisted"
+	JoinRequestStatusApproved   JoinRequestStatus = "approved"
+	JoinRequestStatusRejected   JoinRequestStatus = "rejected"
+	JoinRequestStatusWithdrawn  JoinRequestStatus = "withdrawn"
+)
+
+// JoinRequest is a request of the user to join the club. Only one request per user and club
+// can be pending or waitlisted, decided and withdrawn requests are kept as history.
+type JoinRequest struct {
+	ID          int64
+	ClubID      int64
+	ClubName    string
+	UserID      int64
+	Status      JoinRequestStatus
+	Message     string
+	ReviewerID  int64
+	Note        string
+	RequestedAt time.Time
+	DecidedAt   *time.Time
+}
+
+// JoinRequestDecision is the outcome of deciding on the join request of UserID in bulk, Err is nil on success.
+// Status is the resulting status of the request, approvals of a full club end up waitlisted.
+type JoinRequestDecision struct {
+	UserID int64
+	Status JoinRequestStatus
+	Err    error
+}
+
+// AuditAction returns the audit action recording a join request moved to the status.
+func (s JoinRequestStatus) AuditAction() AuditAction {
+	switch s {
+	case JoinRequestStatusPending:
+		return AuditJoinRequested
+	case JoinRequestStatusWaitlisted:
+		return AuditMembershipWaitlisted
+	case JoinRequestStatusRejected:
+		return AuditMembershipRejected
+	case JoinRequestStatusWithdrawn:
+		return AuditJoinRequestWithdrawn
+	default:
+		return AuditMembershipApproved
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestJoinRequestStatus_AuditAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		status JoinRequestStatus
+		want   AuditAction
+	}{
+		{"Requested", JoinRequestStatusPending, AuditJoinRequested},
+		{"Approved", JoinRequestStatusApproved, AuditMembershipApproved},
+		{"Waitlisted", JoinRequestStatusWaitlisted, AuditMembershipWaitlisted},
+		{"Rejected", JoinRequestStatusRejected, AuditMembershipRejected},
+		{"Withdrawn", JoinRequestStatusWithdrawn, AuditJoinRequestWithdrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.AuditAction(); got != tt.want {
+				t.Errorf("AuditAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
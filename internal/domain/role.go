@@ -1,6 +1,11 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidRoleTemplates = errors.New("invalid default role templates")
 
 type Role struct {
 	ID          int
@@ -8,6 +13,94 @@ type Role struct {
 	Permissions Permissions
 	Position    int
 	Color       int
+	// IsDefault marks the role every club member gets on joining.
+	IsDefault bool
+}
+
+// AuditPayload returns the fields of the role recorded in the audit log.
+func (r Role) AuditPayload() map[string]any {
+	return map[string]any{
+		"id":          r.ID,
+		"name":        r.Name,
+		"permissions": r.Permissions.PermissionsHex,
+		"position":    r.Position,
+		"color":       r.Color,
+	}
+}
+
+// OwnerRoleHandover returns the audit entries of handing the owner roles of the club over from the previous
+// owner to the new one, revoked are the roles taken from the previous owner and assigned the roles given to the new one.
+func OwnerRoleHandover(clubID, actorID, fromUserID, toUserID int64, revoked, assigned []Role) []AuditEntry {
+	entries := make([]AuditEntry, 0, len(revoked)+len(assigned))
+	for _, role := range revoked {
+		entries = append(entries, AuditEntry{
+			ClubID:   clubID,
+			ActorID:  actorID,
+			TargetID: fromUserID,
+			Action:   AuditRoleRevoked,
+			Before:   role.AuditPayload(),
+		})
+	}
+	for _, role := range assigned {
+		entries = append(entries, AuditEntry{
+			ClubID:   clubID,
+			ActorID:  actorID,
+			TargetID: toUserID,
+			Action:   AuditRoleAssigned,
+			After:    role.AuditPayload(),
+		})
+	}
+
+	return entries
+}
+
+// RoleTemplate describes a role created for every club when it is approved.
+type RoleTemplate struct {
+	Name        string
+	Permissions uint64
+	Position    int
+	Color       int
+	// IsDefault marks the role given to every new member, exactly one template must have it.
+	IsDefault bool
+	// ForOwner marks roles given to the club owner on approval, they move to the new owner on a transfer.
+	ForOwner bool
+}
+
+var DefaultRoleTemplates = []RoleTemplate{
+	{Name: "president", Permissions: ALL, Position: 1, Color: 15844367, ForOwner: true},
+	{Name: "member", Permissions: 0, Position: 0, Color: 8223868, IsDefault: true},
+}
+
+// ValidateRoleTemplates checks that there is exactly one default role and at least one owner role.
+func ValidateRoleTemplates(templates []RoleTemplate) error {
+	const op = "domain.role.ValidateRoleTemplates"
+
+	var defaults, owners int
+	positions := make(map[int]bool, len(templates))
+	for _, t := range templates {
+		if t.Name == "" {
+			return fmt.Errorf("%s: %w: empty role name", op, ErrInvalidRoleTemplates)
+		}
+		if positions[t.Position] {
+			return fmt.Errorf("%s: %w: duplicate position %d", op, ErrInvalidRoleTemplates, t.Position)
+		}
+		positions[t.Position] = true
+		if t.IsDefault {
+			defaults++
+		}
+		if t.ForOwner {
+			owners++
+		}
+	}
+
+	if defaults != 1 {
+		return fmt.Errorf("%s: %w: expected exactly one default role, got %d", op, ErrInvalidRoleTemplates, defaults)
+	}
+	if owners == 0 {
+		return fmt.Errorf("%s: %w: no role for club owner", op, ErrInvalidRoleTemplates)
+	}
+
+	return nil
 }
 
 func GetHighestRolePosition(roles []Role) (int, error) {
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRoleTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates []RoleTemplate
+		wantErr   bool
+	}{
+		{"Default templates", DefaultRoleTemplates, false},
+		{"No templates", nil, true},
+		{"No default role", []RoleTemplate{{Name: "president", Position: 1, ForOwner: true}}, true},
+		{"No owner role", []RoleTemplate{{Name: "member", IsDefault: true}}, true},
+		{"Two default roles", []RoleTemplate{
+			{Name: "president", Position: 2, ForOwner: true},
+			{Name: "member", Position: 1, IsDefault: true},
+			{Name: "guest", Position: 0, IsDefault: true},
+		}, true},
+		{"Duplicate positions", []RoleTemplate{
+			{Name: "president", Position: 0, ForOwner: true},
+			{Name: "member", Position: 0, IsDefault: true},
+		}, true},
+		{"Owner role is default", []RoleTemplate{{Name: "member", IsDefault: true, ForOwner: true}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoleTemplates(tt.templates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRoleTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRoleTemplates) {
+				t.Errorf("ValidateRoleTemplates() error = %v, want %v", err, ErrInvalidRoleTemplates)
+			}
+		})
+	}
+}
+
+func TestRole_AuditPayload(t *testing.T) {
+	role := Role{
+		ID:          7,
+		Name:        "treasurer",
+		Permissions: Permissions{PermissionsHex: ManageMembership | KickMember},
+		Position:    3,
+		Color:       255,
+		IsDefault:   true,
+	}
+	want := map[string]any{
+		"id":          7,
+		"name":        "treasurer",
+		"permissions": ManageMembership | KickMember,
+		"position":    3,
+		"color":       255,
+	}
+
+	if got := role.AuditPayload(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AuditPayload() = %v, want %v", got, want)
+	}
+}
+
+func TestOwnerRoleHandover(t *testing.T) {
+	const clubID, actorID, fromUserID, toUserID = 1, 2, 3, 4
+	president := Role{ID: 10, Name: "president", Position: 5}
+	treasurer := Role{ID: 11, Name: "treasurer", Position: 4}
+
+	tests := []struct {
+		name     string
+		revoked  []Role
+		assigned []Role
+		want     []AuditEntry
+	}{
+		{
+			name:     "Roles move to the new owner",
+			revoked:  []Role{president, treasurer},
+			assigned: []Role{president, treasurer},
+			want: []AuditEntry{
+				{ClubID: clubID, ActorID: actorID, TargetID: fromUserID, Action: AuditRoleRevoked, Before: president.AuditPayload()},
+				{ClubID: clubID, ActorID: actorID, TargetID: fromUserID, Action: AuditRoleRevoked, Before: treasurer.AuditPayload()},
+				{ClubID: clubID, ActorID: actorID, TargetID: toUserID, Action: AuditRoleAssigned, After: president.AuditPayload()},
+				{ClubID: clubID, ActorID: actorID, TargetID: toUserID, Action: AuditRoleAssigned, After: treasurer.AuditPayload()},
+			},
+		},
+		{
+			name:     "New owner already holds a role",
+			revoked:  []Role{president, treasurer},
+			assigned: []Role{president},
+			want: []AuditEntry{
+				{ClubID: clubID, ActorID: actorID, TargetID: fromUserID, Action: AuditRoleRevoked, Before: president.AuditPayload()},
+				{ClubID: clubID, ActorID: actorID, TargetID: fromUserID, Action: AuditRoleRevoked, Before: treasurer.AuditPayload()},
+				{ClubID: clubID, ActorID: actorID, TargetID: toUserID, Action: AuditRoleAssigned, After: president.AuditPayload()},
+			},
+		},
+		{
+			name: "Nothing changes",
+			want: []AuditEntry{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OwnerRoleHandover(clubID, actorID, fromUserID, toUserID, tt.revoked, tt.assigned)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OwnerRoleHandover() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
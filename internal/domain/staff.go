@@ -0,0 +1,20 @@
+package domain
+
+import "slices"
+
+// Platform roles are granted by the user service in access token claims,
+// they are not related to club roles.
+const (
+	PlatformRoleAdmin     = "admin"
+	PlatformRoleModerator = "moderator"
+)
+
+// IsStaff reports whether platform roles allow reviewing and moderating any club.
+func IsStaff(platformRoles []string) bool {
+	return IsPlatformAdmin(platformRoles) || slices.Contains(platformRoles, PlatformRoleModerator)
+}
+
+// IsPlatformAdmin reports whether platform roles allow overriding club ownership.
+func IsPlatformAdmin(platformRoles []string) bool {
+	return slices.Contains(platformRoles, PlatformRoleAdmin)
+}
@@ -19,6 +19,8 @@ func (u User) ToUserObject() *clubv1.UserObject {
 		roles[i] = &clubv1.Role{
 			Name:        role.Name,
 			Permissions: role.Permissions.PermissionsArr,
+			Position:    int32(role.Position),
+			Color:       int32(role.Color),
 		}
 	}
 
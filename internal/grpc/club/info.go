@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
-	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/management"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/info"
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"google.golang.org/grpc/codes"
@@ -13,6 +13,7 @@ import (
 
 type InfoService interface {
 	GetClub(ctx context.Context, clubID int64) (*domain.Club, error)
+	GetClubIncludingInactive(ctx context.Context, clubID int64) (*domain.Club, error)
 	GetUserClubs(ctx context.Context, userID int64) ([]*domain.Club, error)
 	ListClub(
 		ctx context.Context,
@@ -34,6 +35,16 @@ type InfoService interface {
 	)
 	ListClubMembers(ctx context.Context, clubID int64, filters domain.Filters) ([]*domain.User, *domain.Metadata, error)
 	ListClubJoinReq(ctx context.Context, clubID int64, filters domain.Filters) ([]*domain.User, *domain.Metadata, error)
+	ListUserClubApplications(ctx context.Context, userID int64, filters domain.Filters) (
+		[]*domain.ClubApplication,
+		*domain.Metadata,
+		error,
+	)
+	ListUserJoinRequests(ctx context.Context, userID int64, filters domain.Filters) (
+		[]*domain.JoinRequest,
+		*domain.Metadata,
+		error,
+	)
 }
 
 func (s serverApi) GetClub(ctx context.Context, req *clubv1.GetClubRequest) (*clubv1.ClubObject, error) {
@@ -42,9 +53,15 @@ func (s serverApi) GetClub(ctx context.Context, req *clubv1.GetClubRequest) (*cl
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	club, err := s.info.GetClub(ctx, req.GetClubId())
+	// Staff can see pending, rejected and deactivated clubs too.
+	getClub := s.info.GetClub
+	if domain.IsStaff(platformRoles(ctx)) {
+		getClub = s.info.GetClubIncludingInactive
+	}
+
+	club, err := getClub(ctx, req.GetClubId())
 	if err != nil {
-		if errors.Is(err, management.ErrClubNotExists) {
+		if errors.Is(err, info.ErrClubNotExists) {
 			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
 		}
 		return nil, status.Error(codes.Internal, ErrInternal.Error())
@@ -80,6 +97,10 @@ func (s serverApi) ListClubs(ctx context.Context, req *clubv1.ListClubRequest) (
 }
 
 func (s serverApi) ListNotApprovedClubs(ctx context.Context, req *clubv1.ListNotApprovedClubsRequest) (*clubv1.ListNotApprovedClubsResponse, error) {
+	if !domain.IsStaff(platformRoles(ctx)) {
+		return nil, status.Error(codes.PermissionDenied, ErrUserNotStaff.Error())
+	}
+
 	err := validation.ValidateStruct(req,
 		validation.Field(&req.PageNumber, validation.Required, validation.Min(1)),
 		validation.Field(&req.PageSize, validation.Required, validation.Min(1)),
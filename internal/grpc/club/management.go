@@ -2,7 +2,12 @@ package club
 
 import (
 	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/info"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/management"
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -12,13 +17,24 @@ import (
 
 type ManagementService interface {
 	CreateClub(ctx context.Context, dto dtos.CreateClubDTO) error
-	ApproveClub(ctx context.Context, clubID int64) error
-	RejectClub(ctx context.Context, clubID int64) error
+	ApproveClub(ctx context.Context, clubID, actorID int64) error
+	RejectClub(ctx context.Context, clubID, actorID int64, reason string) error
+	ResubmitClub(ctx context.Context, dto dtos.UpdateClubDTO) error
+	UpdateClub(ctx context.Context, dto dtos.UpdateClubDTO) error
+	DeactivateClub(ctx context.Context, clubID, actorID int64) error
+	ReactivateClub(ctx context.Context, clubID, actorID int64) error
+	UpdateLogo(ctx context.Context, clubID, actorID int64, logo []byte) (*domain.Club, error)
+	UpdateBanner(ctx context.Context, clubID, actorID int64, banner []byte) (*domain.Club, error)
+	TransferOwnership(ctx context.Context, clubID, actorID, newOwnerID int64, platformRoles []string) error
 }
 
 func (s serverApi) CreateClub(ctx context.Context, req *clubv1.CreateClubRequest) (*empty.Empty, error) {
-	err := validation.ValidateStruct(req,
-		validation.Field(&req.OwnerId, validation.Required, validation.Min(1)),
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
 		validation.Field(&req.Name, validation.Required, validation.Length(3, 250)),
 		validation.Field(&req.ClubType, validation.Required, validation.Length(3, 250)),
 	)
@@ -26,7 +42,10 @@ func (s serverApi) CreateClub(ctx context.Context, req *clubv1.CreateClubRequest
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = s.management.CreateClub(ctx, dtos.CreateClubRequestToDTO(req))
+	dto := dtos.CreateClubRequestToDTO(req)
+	dto.OwnerID = userID
+
+	err = s.management.CreateClub(ctx, dto)
 	if err != nil {
 		return nil, status.Error(codes.Internal, ErrInternal.Error())
 	}
@@ -36,7 +55,15 @@ func (s serverApi) CreateClub(ctx context.Context, req *clubv1.CreateClubRequest
 }
 
 func (s serverApi) HandleNewClub(ctx context.Context, req *clubv1.HandleNewClubRequest) (*empty.Empty, error) {
-	err := validation.ValidateStruct(req,
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsStaff(platformRoles(ctx)) {
+		return nil, status.Error(codes.PermissionDenied, ErrUserNotStaff.Error())
+	}
+
+	err = validation.ValidateStruct(req,
 		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
 	)
 	if err != nil {
@@ -44,23 +71,111 @@ func (s serverApi) HandleNewClub(ctx context.Context, req *clubv1.HandleNewClubR
 	}
 
 	if req.GetAction() == clubv1.HandleClubAction_APPROVE {
-		err = s.management.ApproveClub(ctx, req.GetClubId())
+		err = s.management.ApproveClub(ctx, req.GetClubId(), userID)
 	} else {
-		err = s.management.RejectClub(ctx, req.GetClubId())
+		// HandleNewClubRequest has no reason field yet.
+		err = s.management.RejectClub(ctx, req.GetClubId(), userID, "")
 	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, ErrInternal.Error())
+		switch {
+		case errors.Is(err, management.ErrClubNotExists):
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		case errors.Is(err, management.ErrInvalidStatusTransition):
+			return nil, status.Error(codes.FailedPrecondition, ErrInvalidClubStatus.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
 	}
 
 	return &empty.Empty{}, nil
 }
 
 func (s serverApi) DeactivateClub(ctx context.Context, req *clubv1.DeactivateClubRequest) (*empty.Empty, error) {
-	//TODO implement me
-	panic("implement me")
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
+		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Staff can deactivate any club, otherwise only club administrators.
+	if !domain.IsStaff(platformRoles(ctx)) {
+		isAuthorized, err := s.permission.HasPermission(ctx, req.GetClubId(), userID, domain.Administrator)
+		if err != nil {
+			if errors.Is(err, accessControl.ErrUserNotClubMember) {
+				return nil, status.Error(codes.PermissionDenied, ErrUserNotClubMember.Error())
+			}
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
+		if !isAuthorized {
+			return nil, status.Error(codes.PermissionDenied, ErrUserNonAuthorized.Error())
+		}
+	}
+
+	err = s.management.DeactivateClub(ctx, req.GetClubId(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, management.ErrClubNotExists):
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		case errors.Is(err, management.ErrInvalidStatusTransition):
+			return nil, status.Error(codes.FailedPrecondition, ErrInvalidClubStatus.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
+	}
+
+	return &empty.Empty{}, nil
 }
 
 func (s serverApi) UpdateClub(ctx context.Context, req *clubv1.UpdateClubRequest) (*empty.Empty, error) {
-	//TODO implement me
-	panic("implement me")
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := dtos.UpdateClubRequestToDTO(req)
+	dto.UserID = userID
+
+	err = validation.ValidateStruct(&dto,
+		validation.Field(&dto.ClubID, validation.Required, validation.Min(1)),
+		validation.Field(&dto.Name, validation.NilOrNotEmpty, validation.Length(3, 250)),
+		validation.Field(&dto.ClubType, validation.NilOrNotEmpty, validation.Length(3, 250)),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = s.info.GetClub(ctx, dto.ClubID)
+	if err != nil {
+		if errors.Is(err, info.ErrClubNotExists) {
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, ErrInternal.Error())
+	}
+
+	isAuthorized, err := s.permission.HasPermission(ctx, dto.ClubID, dto.UserID, domain.ManageClub)
+	if err != nil {
+		if errors.Is(err, accessControl.ErrUserNotClubMember) {
+			return nil, status.Error(codes.PermissionDenied, ErrUserNotClubMember.Error())
+		}
+		return nil, status.Error(codes.Internal, ErrInternal.Error())
+	}
+	if !isAuthorized {
+		return nil, status.Error(codes.PermissionDenied, ErrUserNonAuthorized.Error())
+	}
+
+	err = s.management.UpdateClub(ctx, dto)
+	if err != nil {
+		if errors.Is(err, management.ErrClubNotExists) {
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, ErrInternal.Error())
+	}
+
+	return &empty.Empty{}, nil
 }
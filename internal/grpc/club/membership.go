@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/membership"
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -12,39 +13,67 @@ import (
 )
 
 type MembershipService interface {
-	CreateJoinRequest(ctx context.Context, userID, clubID int64) error
-	ApproveMembership(ctx context.Context, clubID, userID int64) error
-	RejectMembership(ctx context.Context, clubID, userID int64) error
+	CreateJoinRequest(ctx context.Context, userID, clubID int64, message string) error
+	ApproveMembership(ctx context.Context, clubID, actorID, userID int64) error
+	RejectMembership(ctx context.Context, clubID, actorID, userID int64, note string) error
+	WithdrawJoinRequest(ctx context.Context, clubID, userID int64) error
+	LeaveClub(ctx context.Context, clubID, userID int64) error
 }
 
 func (s serverApi) RequestToJoinClub(ctx context.Context, req *clubv1.RequestToJoinClubRequest) (*empty.Empty, error) {
-	err := validation.ValidateStruct(req,
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
 		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
-		validation.Field(&req.UserId, validation.Required, validation.Min(1)),
 	)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = s.membership.CreateJoinRequest(ctx, req.GetUserId(), req.GetClubId())
+	// RequestToJoinClubRequest has no message field yet.
+	err = s.membership.CreateJoinRequest(ctx, userID, req.GetClubId(), "")
 	if err != nil {
-		return nil, status.Error(codes.Internal, ErrInternal.Error())
+		switch {
+		case errors.Is(err, membership.ErrClubNotExists):
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		case errors.Is(err, membership.ErrClubNotActive):
+			return nil, status.Error(codes.FailedPrecondition, ErrClubNotActive.Error())
+		case errors.Is(err, membership.ErrUserBanned):
+			return nil, status.Error(codes.PermissionDenied, ErrUserBanned.Error())
+		case errors.Is(err, membership.ErrUserIsClubMember):
+			return nil, status.Error(codes.AlreadyExists, ErrUserAlreadyMember.Error())
+		case errors.Is(err, membership.ErrJoinRequestExists):
+			return nil, status.Error(codes.AlreadyExists, ErrJoinRequestExists.Error())
+		case errors.Is(err, membership.ErrClubInviteOnly):
+			return nil, status.Error(codes.FailedPrecondition, ErrClubInviteOnly.Error())
+		case errors.Is(err, membership.ErrClubClosed):
+			return nil, status.Error(codes.FailedPrecondition, ErrClubClosed.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
 	}
 
 	return &empty.Empty{}, nil
 }
 
 func (s serverApi) HandleJoinClub(ctx context.Context, req *clubv1.HandleJoinClubRequest) (*empty.Empty, error) {
-	err := validation.ValidateStruct(req,
+	memberID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
 		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
 		validation.Field(&req.UserId, validation.Required, validation.Min(1)),
-		validation.Field(&req.MemberId, validation.Required, validation.Min(1)),
 	)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	isAuthorized, err := s.permission.CanHandleMembershipRequest(ctx, req.GetClubId(), req.GetMemberId())
+	isAuthorized, err := s.permission.CanHandleMembershipRequest(ctx, req.GetClubId(), memberID)
 	if err != nil {
 		if errors.Is(err, accessControl.ErrUserNotClubMember) {
 			return nil, status.Error(codes.PermissionDenied, ErrUserNotClubMember.Error())
@@ -57,17 +86,51 @@ func (s serverApi) HandleJoinClub(ctx context.Context, req *clubv1.HandleJoinClu
 
 	switch req.GetAction() {
 	case clubv1.HandleClubAction_APPROVE:
-		err = s.membership.ApproveMembership(ctx, req.GetClubId(), req.GetUserId())
+		err = s.membership.ApproveMembership(ctx, req.GetClubId(), memberID, req.GetUserId())
 	default:
-		err = s.membership.RejectMembership(ctx, req.GetClubId(), req.GetUserId())
+		// HandleJoinClubRequest has no note field yet.
+		err = s.membership.RejectMembership(ctx, req.GetClubId(), memberID, req.GetUserId(), "")
 	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, ErrInternal.Error())
+		switch {
+		case errors.Is(err, membership.ErrJoinRequestNotExists):
+			return nil, status.Error(codes.NotFound, ErrJoinRequestNotFound.Error())
+		case errors.Is(err, membership.ErrClubNotActive):
+			return nil, status.Error(codes.FailedPrecondition, ErrClubNotActive.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
 	}
 
 	return &empty.Empty{}, nil
 }
 
-func (s serverApi) LeaveClub(context.Context, *clubv1.LeaveClubRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LeaveClub not implemented")
+func (s serverApi) LeaveClub(ctx context.Context, req *clubv1.LeaveClubRequest) (*empty.Empty, error) {
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
+		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.membership.LeaveClub(ctx, req.GetClubId(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, membership.ErrClubNotExists):
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		case errors.Is(err, membership.ErrUserNotClubMember):
+			return nil, status.Error(codes.NotFound, ErrUserNotClubMember.Error())
+		case errors.Is(err, membership.ErrUserIsClubOwner):
+			return nil, status.Error(codes.FailedPrecondition, ErrOwnerCannotLeave.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
+	}
+
+	return &empty.Empty{}, nil
 }
@@ -0,0 +1,16 @@
+package club
+
+import (
+	"context"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+)
+
+type RoleService interface {
+	CreateRole(ctx context.Context, dto dtos.CreateRoleDTO) (*domain.Role, error)
+	UpdateRole(ctx context.Context, dto dtos.UpdateRoleDTO) (*domain.Role, error)
+	MoveRole(ctx context.Context, clubID, actorID int64, roleID int, position int) error
+	DeleteRole(ctx context.Context, clubID, actorID int64, roleID int) error
+	AssignRole(ctx context.Context, clubID, actorID, targetID int64, roleID int) (*domain.User, error)
+	RevokeRole(ctx context.Context, clubID, actorID, targetID int64, roleID int) (*domain.User, error)
+}
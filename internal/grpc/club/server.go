@@ -3,8 +3,16 @@ package club
 import (
 	"context"
 	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/auth"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/info"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/management"
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	validation "github.com/go-ozzo/ozzo-validation"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -13,6 +21,17 @@ var (
 	ErrUserNotClubMember   = errors.New("user is not club member or club_id is not correct")
 	ErrTargetNotClubMember = errors.New("target user is not club member")
 	ErrUserNonAuthorized   = errors.New("user does not have permission")
+	ErrClubNotActive       = errors.New("club is not active")
+	ErrInvalidClubStatus   = errors.New("club status does not allow this operation")
+	ErrOwnerCannotLeave    = errors.New("club owner must transfer ownership before leaving")
+	ErrUserBanned          = errors.New("user is banned from club")
+	ErrUserAlreadyMember   = errors.New("user is already club member")
+	ErrJoinRequestExists   = errors.New("join request is already pending")
+	ErrJoinRequestNotFound = errors.New("pending join request not found")
+	ErrClubInviteOnly      = errors.New("club accepts members by invitation only")
+	ErrClubClosed          = errors.New("club does not accept new members")
+	ErrUnauthenticated     = errors.New("user is not authenticated")
+	ErrUserNotStaff        = errors.New("user is not platform staff")
 )
 
 type serverApi struct {
@@ -21,11 +40,13 @@ type serverApi struct {
 	info       InfoService
 	membership MembershipService
 	permission PermissionService
+	role       RoleService
 }
 
 type PermissionService interface {
 	CanActOnMember(ctx context.Context, clubID, userID, targetID int64, permission uint64) (bool, error)
 	CanHandleMembershipRequest(ctx context.Context, clubID, userID int64) (bool, error)
+	HasPermission(ctx context.Context, clubID, userID int64, permission uint64) (bool, error)
 }
 
 func Register(
@@ -34,16 +55,80 @@ func Register(
 	membership MembershipService,
 	info InfoService,
 	permission PermissionService,
+	role RoleService,
 ) {
 	clubv1.RegisterClubServer(gRPC, &serverApi{
 		management: management,
 		membership: membership,
 		info:       info,
 		permission: permission,
+		role:       role,
 	})
 }
 
-func (s serverApi) UpdateLogo(ctx context.Context, request *clubv1.UpdateLogoRequest) (*clubv1.ClubObject, error) {
-	//TODO implement me
-	panic("implement me")
+// actorID returns the id of the authenticated user, user ids in request bodies are not trusted.
+func actorID(ctx context.Context) (int64, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, ErrUnauthenticated.Error())
+	}
+	return identity.UserID, nil
+}
+
+// platformRoles returns platform roles of the authenticated user.
+func platformRoles(ctx context.Context) []string {
+	identity, _ := auth.IdentityFromContext(ctx)
+	return identity.Roles
+}
+
+func (s serverApi) UpdateLogo(ctx context.Context, req *clubv1.UpdateLogoRequest) (*clubv1.ClubObject, error) {
+	userID, err := actorID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validation.ValidateStruct(req,
+		validation.Field(&req.ClubId, validation.Required, validation.Min(1)),
+		validation.Field(&req.Logo, validation.Required),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = s.info.GetClub(ctx, req.GetClubId())
+	if err != nil {
+		if errors.Is(err, info.ErrClubNotExists) {
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		}
+		return nil, status.Error(codes.Internal, ErrInternal.Error())
+	}
+
+	isAuthorized, err := s.permission.HasPermission(ctx, req.GetClubId(), userID, domain.ManageClub)
+	if err != nil {
+		if errors.Is(err, accessControl.ErrUserNotClubMember) {
+			return nil, status.Error(codes.PermissionDenied, ErrUserNotClubMember.Error())
+		}
+		return nil, status.Error(codes.Internal, ErrInternal.Error())
+	}
+	if !isAuthorized {
+		return nil, status.Error(codes.PermissionDenied, ErrUserNonAuthorized.Error())
+	}
+
+	club, err := s.management.UpdateLogo(ctx, req.GetClubId(), userID, req.GetLogo())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnsupportedImageType):
+			return nil, status.Error(codes.InvalidArgument, domain.ErrUnsupportedImageType.Error())
+		case errors.Is(err, domain.ErrImageTooLarge):
+			return nil, status.Error(codes.InvalidArgument, domain.ErrImageTooLarge.Error())
+		case errors.Is(err, domain.ErrInvalidImageSize):
+			return nil, status.Error(codes.InvalidArgument, domain.ErrInvalidImageSize.Error())
+		case errors.Is(err, management.ErrClubNotExists):
+			return nil, status.Error(codes.NotFound, ErrClubNotFound.Error())
+		default:
+			return nil, status.Error(codes.Internal, ErrInternal.Error())
+		}
+	}
+
+	return club.ToClubObject(), nil
 }
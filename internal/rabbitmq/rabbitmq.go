@@ -1,62 +1,339 @@
 package rabbitmq
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/config"
 	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
 	"github.com/rabbitmq/amqp091-go"
 	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	reconnectBaseDelay = time.Second
+	reconnectMaxDelay  = 30 * time.Second
+)
+
+var (
+	ErrNotConnected = errors.New("not connected to amqp server")
+	ErrNotConfirmed = errors.New("message was not confirmed by amqp server")
+	ErrUnroutable   = errors.New("message was returned as unroutable")
+	ErrClosed       = errors.New("amqp connection is closed")
 )
 
 type Handler func(msg amqp091.Delivery) error
 
+// Rabbitmq holds a supervised connection, it reconnects with backoff when the connection or
+// channel is closed by the broker. The supervisor starts the registered consumers and restarts them
+// after a reconnect or a failed start.
+// Messages are published on a separate channel in confirm mode.
 type Rabbitmq struct {
-	conn *amqp091.Connection
-	ch   *amqp091.Channel
-	cfg  config.Rabbitmq
-	log  *slog.Logger
+	mu        sync.RWMutex
+	conn      *amqp091.Connection
+	ch        *amqp091.Channel
+	pubCh     *amqp091.Channel
+	returns   chan amqp091.Return
+	consumers []*consumer
+	onStatus  []func(healthy bool)
+	// wake tells the supervisor that a consumer was registered.
+	wake chan struct{}
+
+	// pubMu serializes publishing, so a returned message is matched to the waiting Publish.
+	pubMu     sync.Mutex
+	published uint64
+
+	healthy atomic.Bool
+	done    chan struct{}
+	once    sync.Once
+
+	cfg config.Rabbitmq
+	log *slog.Logger
+}
+
+type consumer struct {
+	queue  string
+	router *Router
+	// running is set once the consumer is started on the current channel, guarded by mu.
+	running bool
 }
 
 func New(cfg config.Rabbitmq, log *slog.Logger) (*Rabbitmq, error) {
 	const op = "Rabbitmq.New"
 
-	connString := fmt.Sprintf("amqp://%v:%v@%v:%v/", cfg.User, cfg.Password, cfg.Host, cfg.Port)
+	r := &Rabbitmq{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+		cfg:  cfg,
+		log:  log,
+	}
+
+	if err := r.connect(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	go r.supervise()
+
+	return r, nil
+}
+
+func (r *Rabbitmq) connect() error {
+	connString := fmt.Sprintf("amqp://%v:%v@%v:%v/", r.cfg.User, r.cfg.Password, r.cfg.Host, r.cfg.Port)
 	conn, err := amqp091.Dial(connString)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to connect to amqp server: %w", op, err)
+		return fmt.Errorf("failed to connect to amqp server: %w", err)
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to open a channel: %w", op, err)
+		conn.Close()
+		return fmt.Errorf("failed to open a channel: %w", err)
 	}
 
-	return &Rabbitmq{
-		conn: conn,
-		ch:   ch,
-		cfg:  cfg,
-		log:  log,
-	}, nil
+	err = ch.Qos(
+		1,
+		0,
+		false,
+	)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set Qos: %w", err)
+	}
+
+	pubCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open a publishing channel: %w", err)
+	}
+
+	err = pubCh.Confirm(false)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to put publishing channel in confirm mode: %w", err)
+	}
+	returns := pubCh.NotifyReturn(make(chan amqp091.Return, 1))
+
+	r.mu.Lock()
+	r.conn, r.ch, r.pubCh, r.returns = conn, ch, pubCh, returns
+	r.mu.Unlock()
+
+	r.setHealthy(true)
+
+	return nil
+}
+
+// supervise starts the registered consumers and retries the ones that failed to start, waits for
+// the connection or channel to close and reconnects until Close is called.
+func (r *Rabbitmq) supervise() {
+	const op = "Rabbitmq.supervise"
+	log := r.log.With(slog.String("op", op))
+
+	for {
+		r.mu.RLock()
+		conn, ch, pubCh := r.conn, r.ch, r.pubCh
+		r.mu.RUnlock()
+
+		connClosed := conn.NotifyClose(make(chan *amqp091.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp091.Error, 1))
+		pubChClosed := pubCh.NotifyClose(make(chan *amqp091.Error, 1))
+
+		var reason *amqp091.Error
+		failures := 0
+	watch:
+		for {
+			var retry <-chan time.Time
+			if r.startConsumers(log) {
+				failures = 0
+			} else {
+				failures++
+				retry = time.After(retryDelay(failures, reconnectBaseDelay, reconnectMaxDelay))
+			}
+
+			select {
+			case <-r.done:
+				return
+			case <-r.wake:
+			case <-retry:
+			case reason = <-connClosed:
+				break watch
+			case reason = <-chClosed:
+				// The connection may be alive, close it to recover everything the same way.
+				conn.Close()
+				break watch
+			case reason = <-pubChClosed:
+				conn.Close()
+				break watch
+			}
+		}
+
+		r.setHealthy(false)
+		r.stopConsumers()
+		log.Warn("amqp connection lost", slog.Any("reason", reason))
+
+		for attempt := 1; ; attempt++ {
+			select {
+			case <-r.done:
+				return
+			case <-time.After(retryDelay(attempt, reconnectBaseDelay, reconnectMaxDelay)):
+			}
+
+			err := r.connect()
+			if err != nil {
+				log.Warn("failed to reconnect", slog.Int("attempt", attempt), logger.Err(err))
+				continue
+			}
+			break
+		}
+
+		log.Info("amqp connection recovered")
+	}
+}
+
+// startConsumers starts every registered consumer that is not running and reports whether all of them run.
+func (r *Rabbitmq) startConsumers(log *slog.Logger) bool {
+	r.mu.RLock()
+	var stopped []*consumer
+	for _, c := range r.consumers {
+		if !c.running {
+			stopped = append(stopped, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	ok := true
+	for _, c := range stopped {
+		if err := r.startConsumer(c.queue, c.router); err != nil {
+			log.Error("failed to start consumer", slog.String("queue", c.queue), logger.Err(err))
+			ok = false
+			continue
+		}
+
+		r.mu.Lock()
+		c.running = true
+		r.mu.Unlock()
+	}
+
+	return ok
+}
+
+// stopConsumers marks every consumer as stopped, their deliveries end with the closed channel.
+func (r *Rabbitmq) stopConsumers() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.consumers {
+		c.running = false
+	}
+}
+
+// Healthy reports whether the connection to the broker is open.
+func (r *Rabbitmq) Healthy() bool {
+	return r.healthy.Load()
+}
+
+// NotifyStatus registers fn to be called with the current status and on every status change.
+func (r *Rabbitmq) NotifyStatus(fn func(healthy bool)) {
+	r.mu.Lock()
+	r.onStatus = append(r.onStatus, fn)
+	r.mu.Unlock()
+
+	fn(r.Healthy())
+}
+
+func (r *Rabbitmq) setHealthy(healthy bool) {
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, fn := range r.onStatus {
+		fn(healthy)
+	}
+}
+
+// Close stops reconnecting and closes the connection, consumers stop after their current delivery.
+func (r *Rabbitmq) Close() error {
+	const op = "Rabbitmq.Close"
+
+	var err error
+	r.once.Do(func() {
+		close(r.done)
+		r.setHealthy(false)
+
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		if !r.conn.IsClosed() {
+			err = r.conn.Close()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Rabbitmq) channel() *amqp091.Channel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ch
 }
 
-func (r *Rabbitmq) Consume(queue, routingKey string, handler func(msg amqp091.Delivery) error) error {
+// Consume registers a consumer that declares queue, binds it to the exchange with every routing key
+// of router and dispatches deliveries to the router. The supervisor starts it and keeps restarting it
+// until it runs, also after every reconnect.
+func (r *Rabbitmq) Consume(queue string, router *Router) error {
 	const op = "Rabbitmq.Consume"
+
+	select {
+	case <-r.done:
+		return fmt.Errorf("%s: %w", op, ErrClosed)
+	default:
+	}
+
+	r.mu.Lock()
+	r.consumers = append(r.consumers, &consumer{queue: queue, router: router})
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+		// The supervisor is already woken up and will see the consumer.
+	}
+
+	return nil
+}
+
+func (r *Rabbitmq) startConsumer(queue string, router *Router) error {
 	log := r.log.With(
-		slog.String("op", op),
-		slog.With("queue", queue),
+		slog.String("op", "Rabbitmq.Consume"),
+		slog.String("queue", queue),
 	)
+	ch := r.channel()
 
-	err := r.ch.Qos(
-		1,
-		0,
-		false,
-	)
+	_, err := ch.QueueDeclare(queue, true, false, false, false, nil)
 	if err != nil {
-		log.Error("failed to set Qos", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("failed to declare queue: %w", err)
 	}
 
-	msgs, err := r.ch.Consume(
+	for _, key := range router.RoutingKeys() {
+		err = ch.QueueBind(queue, key, r.cfg.ExchangeName, false, nil)
+		if err != nil {
+			return fmt.Errorf("failed to bind queue with %s: %w", key, err)
+		}
+	}
+
+	err = r.declareRetryTopology(ch, queue)
+	if err != nil {
+		return fmt.Errorf("failed to declare retry topology: %w", err)
+	}
+
+	msgs, err := ch.Consume(
 		queue,
 		"",
 		false,
@@ -66,37 +343,136 @@ func (r *Rabbitmq) Consume(queue, routingKey string, handler func(msg amqp091.De
 		nil,
 	)
 	if err != nil {
-		log.Error("failed to register as consumer", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("failed to register as consumer: %w", err)
 	}
 
-	var forever chan struct{}
-
 	go func() {
 		for d := range msgs {
-			log.Debug("routing key", slog.String("key", d.RoutingKey))
-			if d.RoutingKey != routingKey {
-				err := d.Reject(true)
-				if err != nil {
-					log.Warn("failed to negatively acknowledge", logger.Err(err))
-				}
-				continue
-			}
+			log.Debug("routing key", slog.String("key", RoutingKey(d)))
 
-			err = handler(d)
+			err := router.Dispatch(d)
 			if err != nil {
-				log.Warn("failed to handle message", logger.Err(err))
+				log.Warn("failed to handle message", slog.String("key", RoutingKey(d)), logger.Err(err))
+				if err = r.handleFailure(ch, queue, d, err); err != nil {
+					log.Error("failed to retry or dead-letter message", logger.Err(err))
+				}
 				continue
 			}
+
 			err = d.Ack(false)
 			if err != nil {
 				log.Warn("failed to send an acknowledgement", logger.Err(err))
 			}
-
 		}
+
+		log.Info("consumer stopped")
 	}()
 
-	<-forever
+	return nil
+}
+
+// Publish sends msg encoded as JSON to the configured exchange with routingKey and waits until the broker
+// confirms it. The message is mandatory, so it fails with ErrUnroutable if no queue is bound to routingKey,
+// and with ErrNotConfirmed if the broker nacks it or the channel closes before the confirmation.
+func (r *Rabbitmq) Publish(ctx context.Context, routingKey string, msg any) error {
+	const op = "Rabbitmq.Publish"
+
+	if !r.Healthy() {
+		return fmt.Errorf("%s: %w", op, ErrNotConnected)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal message: %w", op, err)
+	}
+
+	r.pubMu.Lock()
+	defer r.pubMu.Unlock()
+
+	r.mu.RLock()
+	ch, returns := r.pubCh, r.returns
+	r.mu.RUnlock()
+
+	r.published++
+	messageID := strconv.FormatUint(r.published, 10)
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		r.cfg.ExchangeName,
+		routingKey,
+		true,
+		false,
+		amqp091.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp091.Persistent,
+			MessageId:    messageID,
+			Body:         body,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to publish message: %w", op, err)
+	}
+
+	err = waitConfirmation(ctx, confirmation, returns, messageID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// waitConfirmation waits for the broker to confirm the message with messageID. The broker returns an unroutable
+// mandatory message before acking it, returns of messages that were given up on earlier are skipped.
+func waitConfirmation(
+	ctx context.Context,
+	confirmation *amqp091.DeferredConfirmation,
+	returns <-chan amqp091.Return,
+	messageID string,
+) error {
+	var returned *amqp091.Return
+	checkReturn := func(ret amqp091.Return) {
+		if ret.MessageId == messageID {
+			returned = &ret
+		}
+	}
+
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ret, ok := <-returns:
+			if !ok {
+				// The channel is closed, the confirmation is nacked.
+				returns = nil
+				continue
+			}
+			checkReturn(ret)
+		case <-confirmation.Done():
+			break wait
+		}
+	}
+
+	// The return may be buffered but not received yet when the ack arrives.
+drain:
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				break drain
+			}
+			checkReturn(ret)
+		default:
+			break drain
+		}
+	}
+
+	if returned != nil {
+		return fmt.Errorf("%w: %s", ErrUnroutable, returned.ReplyText)
+	}
+	if !confirmation.Acked() {
+		return ErrNotConfirmed
+	}
 
 	return nil
 }
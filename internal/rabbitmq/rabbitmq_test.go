@@ -0,0 +1,46 @@
+package rabbitmq
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRabbitmq_Consume(t *testing.T) {
+	tests := []struct {
+		name          string
+		closed        bool
+		wantConsumers int
+		wantErr       error
+	}{
+		{"Registers before start", false, 1, nil},
+		{"Closed connection", true, 0, ErrClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rabbitmq{wake: make(chan struct{}, 1), done: make(chan struct{})}
+			if tt.closed {
+				close(r.done)
+			}
+
+			err := r.Consume("club", NewRouter())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Consume() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(r.consumers) != tt.wantConsumers {
+				t.Fatalf("Consume() registered %d consumers, want %d", len(r.consumers), tt.wantConsumers)
+			}
+			if tt.wantConsumers == 0 {
+				return
+			}
+			if r.consumers[0].running {
+				t.Errorf("Consume() started the consumer, want the supervisor to start it")
+			}
+			select {
+			case <-r.wake:
+			default:
+				t.Errorf("Consume() did not wake the supervisor")
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rabbitmq/amqp091-go"
+	"strconv"
+	"time"
+)
+
+// Headers used to carry retry state between deliveries.
+const (
+	headerRetryCount         = "x-retry-count"
+	headerOriginalRoutingKey = "x-original-routing-key"
+	headerOriginalQueue      = "x-original-queue"
+	headerFailureReason      = "x-failure-reason"
+)
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not retryable, e.g. a malformed message.
+// Such messages are sent to the dead-letter exchange right away.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var perr *permanentError
+	return errors.As(err, &perr)
+}
+
+// declareRetryTopology declares the dead-letter exchange with the queue.dead queue and a queue.retry.<ms>
+// delay queue per distinct backoff of the retry attempts. Each delay queue holds its messages for its backoff,
+// a single queue would hold short delays behind long ones as messages only expire at the head.
+// The backoff is part of the name, so a changed retry config declares new queues instead of redeclaring
+// existing ones with a different TTL, queues of the old config still drain their messages back to queue.
+// Expired messages are dead-lettered back to queue through the default exchange.
+func (r *Rabbitmq) declareRetryTopology(ch *amqp091.Channel, queue string) error {
+	err := ch.ExchangeDeclare(r.cfg.DeadLetterExchange, amqp091.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	_, err = ch.QueueDeclare(deadQueue(queue), true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+
+	err = ch.QueueBind(deadQueue(queue), queue+".#", r.cfg.DeadLetterExchange, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	for attempt := 1; attempt < r.cfg.Retry.MaxAttempts; attempt++ {
+		delay := retryDelay(attempt, r.cfg.Retry.BaseDelay, r.cfg.Retry.MaxDelay)
+		_, err = ch.QueueDeclare(retryQueue(queue, delay), true, false, false, false, amqp091.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare retry queue for attempt %d: %w", attempt, err)
+		}
+	}
+
+	return nil
+}
+
+// handleFailure schedules a retry of the delivery or dead-letters it and acknowledges the original.
+// The delivery is requeued if neither could be published, so it is not lost.
+func (r *Rabbitmq) handleFailure(ch *amqp091.Channel, queue string, d amqp091.Delivery, handlerErr error) error {
+	attempt := retryCount(d) + 1
+
+	headers := amqp091.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[headerRetryCount] = int64(attempt)
+	headers[headerOriginalRoutingKey] = RoutingKey(d)
+	headers[headerOriginalQueue] = queue
+
+	msg := amqp091.Publishing{
+		Headers:      headers,
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp091.Persistent,
+		MessageId:    d.MessageId,
+		Timestamp:    d.Timestamp,
+		Type:         d.Type,
+		Body:         d.Body,
+	}
+
+	var exchange, key string
+	if IsPermanent(handlerErr) || attempt >= r.cfg.Retry.MaxAttempts {
+		headers[headerFailureReason] = handlerErr.Error()
+		exchange, key = r.cfg.DeadLetterExchange, queue+"."+RoutingKey(d)
+	} else {
+		exchange, key = "", retryQueue(queue, retryDelay(attempt, r.cfg.Retry.BaseDelay, r.cfg.Retry.MaxDelay))
+	}
+
+	err := ch.PublishWithContext(context.Background(), exchange, key, false, false, msg)
+	if err != nil {
+		if nackErr := d.Nack(false, true); nackErr != nil {
+			return errors.Join(err, nackErr)
+		}
+		return err
+	}
+
+	return d.Ack(false)
+}
+
+// RoutingKey returns the routing key the message was originally published with,
+// retried messages are delivered back with the queue name as the routing key.
+func RoutingKey(d amqp091.Delivery) string {
+	if key, ok := d.Headers[headerOriginalRoutingKey].(string); ok {
+		return key
+	}
+	return d.RoutingKey
+}
+
+func retryCount(d amqp091.Delivery) int {
+	switch v := d.Headers[headerRetryCount].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// retryDelay returns the exponential backoff before the given attempt, capped by maxDelay.
+func retryDelay(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
+
+// retryQueue returns the delay queue holding messages for delay.
+func retryQueue(queue string, delay time.Duration) string {
+	return queue + ".retry." + strconv.FormatInt(delay.Milliseconds(), 10)
+}
+
+func deadQueue(queue string) string {
+	return queue + ".dead"
+}
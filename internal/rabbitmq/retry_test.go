@@ -0,0 +1,74 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{"First retry", 1, time.Second},
+		{"Second retry", 2, 2 * time.Second},
+		{"Fourth retry", 4, 8 * time.Second},
+		{"Capped by max delay", 10, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.attempt, time.Second, 30*time.Second); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	errBad := errors.New("bad message")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Transient error", errBad, false},
+		{"Permanent error", Permanent(errBad), true},
+		{"Wrapped permanent error", fmt.Errorf("op: %w", Permanent(errBad)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(tt.err, errBad) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, errBad)
+			}
+		})
+	}
+}
+
+func TestRetryQueue(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  string
+	}{
+		{"One second", time.Second, "club.user.retry.1000"},
+		{"Sub-second", 250 * time.Millisecond, "club.user.retry.250"},
+		{"Five minutes", 5 * time.Minute, "club.user.retry.300000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryQueue("club.user", tt.delay); got != tt.want {
+				t.Errorf("retryQueue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
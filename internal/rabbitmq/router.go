@@ -0,0 +1,44 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rabbitmq/amqp091-go"
+	"sort"
+)
+
+var ErrUnknownRoutingKey = errors.New("no handler for routing key")
+
+// Router dispatches deliveries of a queue to handlers by routing key.
+type Router struct {
+	routes map[string]Handler
+}
+
+func NewRouter() *Router {
+	return &Router{routes: make(map[string]Handler)}
+}
+
+// Handle registers handler for routingKey, the queue is bound to the exchange with every registered key.
+func (r *Router) Handle(routingKey string, handler Handler) {
+	r.routes[routingKey] = handler
+}
+
+// RoutingKeys returns registered routing keys in sorted order.
+func (r *Router) RoutingKeys() []string {
+	keys := make([]string, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Dispatch calls the handler registered for the routing key of d.
+// Deliveries with unknown routing keys fail permanently, so they are dead-lettered.
+func (r *Router) Dispatch(d amqp091.Delivery) error {
+	handler, ok := r.routes[RoutingKey(d)]
+	if !ok {
+		return Permanent(fmt.Errorf("%w: %s", ErrUnknownRoutingKey, RoutingKey(d)))
+	}
+	return handler(d)
+}
@@ -0,0 +1,46 @@
+package rabbitmq
+
+import (
+	"errors"
+	"github.com/rabbitmq/amqp091-go"
+	"testing"
+)
+
+func TestRouter_Dispatch(t *testing.T) {
+	var handled string
+	router := NewRouter()
+	router.Handle("user.club.activated", func(d amqp091.Delivery) error {
+		handled = RoutingKey(d)
+		return nil
+	})
+
+	tests := []struct {
+		name        string
+		delivery    amqp091.Delivery
+		wantHandled string
+		wantErr     error
+	}{
+		{"Known routing key", amqp091.Delivery{RoutingKey: "user.club.activated"}, "user.club.activated", nil},
+		{"Retried delivery", amqp091.Delivery{
+			RoutingKey: "club",
+			Headers:    amqp091.Table{headerOriginalRoutingKey: "user.club.activated"},
+		}, "user.club.activated", nil},
+		{"Unknown routing key", amqp091.Delivery{RoutingKey: "user.club.unknown"}, "", ErrUnknownRoutingKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			err := router.Dispatch(tt.delivery)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Dispatch() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !IsPermanent(err) {
+				t.Errorf("Dispatch() error = %v, want permanent error", err)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handled %q, want %q", handled, tt.wantHandled)
+			}
+		})
+	}
+}
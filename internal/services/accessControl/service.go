@@ -113,3 +113,67 @@ func (s *Service) CanHandleMembershipRequest(ctx context.Context, clubID, userID
 	return domain.HasPermission(userPermissions, domain.ManageMembership), nil
 
 }
+
+func (s *Service) HasPermission(ctx context.Context, clubID, userID int64, permission uint64) (bool, error) {
+	const op = "service.accessControl.HasPermission"
+	log := s.log.With(slog.String("op", op))
+
+	userRoles, isUserOwner, err := s.storage.GetUserRoles(ctx, clubID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotClubMember):
+			log.Error("user is not club member", logger.Err(err))
+			return false, fmt.Errorf("%s: %w", op, ErrUserNotClubMember)
+		default:
+			log.Error("failed to get user permissions", logger.Err(err))
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if isUserOwner {
+		return true, nil
+	}
+
+	userPermissions := domain.AccumulatePermissions(userRoles)
+
+	return domain.HasPermission(userPermissions, permission), nil
+}
+
+// CanManageRole reports whether the user may create or edit a role at position with permissions.
+// Non-owners need ManageRoles, the role has to be below their highest role and
+// they can not grant permissions they do not have.
+func (s *Service) CanManageRole(ctx context.Context, clubID, userID int64, position int, permissions uint64) (bool, error) {
+	const op = "service.accessControl.CanManageRole"
+	log := s.log.With(slog.String("op", op))
+
+	userRoles, isUserOwner, err := s.storage.GetUserRoles(ctx, clubID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotClubMember):
+			log.Error("user is not club member", logger.Err(err))
+			return false, fmt.Errorf("%s: %w", op, ErrUserNotClubMember)
+		default:
+			log.Error("failed to get user permissions", logger.Err(err))
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if isUserOwner {
+		return true, nil
+	}
+
+	userPermissions := domain.AccumulatePermissions(userRoles)
+	if !domain.HasPermission(userPermissions, domain.ManageRoles) {
+		return false, nil
+	}
+
+	userHighestPos, err := domain.GetHighestRolePosition(userRoles)
+	if err != nil {
+		log.Error("failed to get user highest role position", logger.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	if position >= userHighestPos {
+		log.Error(ErrInsufficientRolePosition.Error())
+		return false, fmt.Errorf("%s: %w", op, ErrInsufficientRolePosition)
+	}
+
+	return permissions&^userPermissions == 0, nil
+}
@@ -41,6 +41,16 @@ type Storage interface {
 		error,
 	)
 	ListClubJoinReq(ctx context.Context, clubID int64, filters domain.Filters) ([]*domain.User, *domain.Metadata, error)
+	ListUserClubApplications(ctx context.Context, userID int64, filters domain.Filters) (
+		[]*domain.ClubApplication,
+		*domain.Metadata,
+		error,
+	)
+	ListUserJoinRequests(ctx context.Context, userID int64, filters domain.Filters) (
+		[]*domain.JoinRequest,
+		*domain.Metadata,
+		error,
+	)
 }
 
 func New(log *slog.Logger, storage Storage) *Service {
@@ -54,6 +64,28 @@ func (s Service) GetClub(ctx context.Context, clubID int64) (*domain.Club, error
 	const op = "services.info.GetClub"
 	log := s.log.With(slog.String("op", op))
 
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club by ID", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if club.Status != domain.ClubStatusActive {
+		log.Error("club is not active", slog.String("status", string(club.Status)))
+		return nil, fmt.Errorf("%s: %w", op, ErrClubNotExists)
+	}
+
+	return club, nil
+}
+
+// GetClubIncludingInactive returns the club regardless of its status, it is meant for staff only.
+func (s Service) GetClubIncludingInactive(ctx context.Context, clubID int64) (*domain.Club, error) {
+	const op = "services.info.GetClubIncludingInactive"
+	log := s.log.With(slog.String("op", op))
+
 	club, err := s.storage.GetClubByID(ctx, clubID)
 	if err != nil {
 		if errors.Is(err, storage.ErrClubNotExists) {
@@ -132,3 +164,39 @@ func (s Service) ListClubJoinReq(ctx context.Context, clubID int64, filters doma
 
 	return users, metadata, nil
 }
+
+// ListUserClubApplications returns club applications of the user with their decisions.
+func (s Service) ListUserClubApplications(ctx context.Context, userID int64, filters domain.Filters) (
+	[]*domain.ClubApplication,
+	*domain.Metadata,
+	error,
+) {
+	const op = "services.info.ListUserClubApplications"
+	log := s.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	applications, metadata, err := s.storage.ListUserClubApplications(ctx, userID, filters)
+	if err != nil {
+		log.Error("failed to get club applications of user", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return applications, metadata, nil
+}
+
+// ListUserJoinRequests returns join requests of the user with their decisions.
+func (s Service) ListUserJoinRequests(ctx context.Context, userID int64, filters domain.Filters) (
+	[]*domain.JoinRequest,
+	*domain.Metadata,
+	error,
+) {
+	const op = "services.info.ListUserJoinRequests"
+	log := s.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	requests, metadata, err := s.storage.ListUserJoinRequests(ctx, userID, filters)
+	if err != nil {
+		log.Error("failed to get join requests of user", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requests, metadata, nil
+}
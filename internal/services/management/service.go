@@ -4,31 +4,83 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
 	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
 	"log/slog"
+	"time"
 )
 
 var (
-	ErrFailedToBeginTx = errors.New("failed to begin transaction")
-	ErrClubNotExists   = errors.New("club does not exists")
+	ErrFailedToBeginTx         = errors.New("failed to begin transaction")
+	ErrClubNotExists           = errors.New("club does not exists")
+	ErrInvalidStatusTransition = errors.New("club status does not allow this operation")
+	ErrPermissionDenied        = errors.New("user does not have permission")
+	ErrNewOwnerNotMember       = errors.New("new owner is not club member")
+	ErrAlreadyOwner            = errors.New("user is already club owner")
+	ErrUserNotClubMember       = errors.New("user is not club member")
+	ErrInvalidJoinPolicy       = errors.New("invalid join policy")
+	ErrInvalidMaxMembers       = errors.New("max members must be at least one")
 )
 
 type Service struct {
-	log     *slog.Logger
-	storage Storage
+	log           *slog.Logger
+	storage       Storage
+	fileStorage   FileStorage
+	permission    PermissionChecker
+	roleTemplates []domain.RoleTemplate
 }
 
 type Storage interface {
-	SaveClub(ctx context.Context, dto dtos.CreateClubDTO) error
-	ApproveClub(ctx context.Context, clubID int64) error
-	RejectClub(ctx context.Context, clubID int64) error
+	SaveClub(ctx context.Context, dto dtos.CreateClubDTO, audit domain.AuditEntry) (int64, error)
+	ApproveClub(
+		ctx context.Context,
+		clubID, reviewerID int64,
+		templates []domain.RoleTemplate,
+		audit domain.AuditEntry,
+	) error
+	RejectClub(ctx context.Context, clubID, reviewerID int64, reason string, audit domain.AuditEntry) error
+	ResubmitClub(ctx context.Context, dto dtos.UpdateClubDTO, audit domain.AuditEntry) error
+	UpdateClub(ctx context.Context, dto dtos.UpdateClubDTO, audit domain.AuditEntry) error
+	GetClubByID(ctx context.Context, clubID int64) (*domain.Club, error)
+	SetClubStatus(ctx context.Context, clubID int64, from, to domain.ClubStatus, audit domain.AuditEntry) error
+	UpdateClubImage(ctx context.Context, clubID int64, kind domain.ImageKind, url string, audit domain.AuditEntry) error
+	TransferOwnership(ctx context.Context, clubID, fromUserID, toUserID, actorID int64, audit domain.AuditEntry) error
+	SetJoinPolicy(ctx context.Context, clubID int64, policy domain.JoinPolicy, audit domain.AuditEntry) error
+	SetMaxMembers(ctx context.Context, clubID int64, maxMembers *int, audit domain.AuditEntry) error
+	ListAuditLog(ctx context.Context, filter domain.AuditFilter, filters domain.Filters) (
+		[]*domain.AuditEntry,
+		*domain.Metadata,
+		error,
+	)
 }
 
-func New(log *slog.Logger, storage Storage) *Service {
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, clubID, userID int64, permission uint64) (bool, error)
+}
+
+// FileStorage is a blob store for uploaded club images.
+type FileStorage interface {
+	SaveFile(ctx context.Context, key string, data []byte, contentType string) (url string, err error)
+	DeleteFile(ctx context.Context, key string) error
+}
+
+// New creates management service, roleTemplates are the roles seeded for every approved club.
+func New(
+	log *slog.Logger,
+	storage Storage,
+	fileStorage FileStorage,
+	permission PermissionChecker,
+	roleTemplates []domain.RoleTemplate,
+) *Service {
 	return &Service{
-		log:     log,
-		storage: storage,
+		log:           log,
+		storage:       storage,
+		fileStorage:   fileStorage,
+		permission:    permission,
+		roleTemplates: roleTemplates,
 	}
 }
 
@@ -36,7 +88,11 @@ func (s Service) CreateClub(ctx context.Context, dto dtos.CreateClubDTO) error {
 	const op = "services.management.CreateClub"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.SaveClub(ctx, dto)
+	_, err := s.storage.SaveClub(ctx, dto, domain.AuditEntry{
+		ActorID: dto.OwnerID,
+		Action:  domain.AuditClubCreated,
+		After:   dto,
+	})
 	if err != nil {
 		log.Error("failed to create club", logger.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
@@ -45,12 +101,29 @@ func (s Service) CreateClub(ctx context.Context, dto dtos.CreateClubDTO) error {
 	return nil
 }
 
-func (s Service) ApproveClub(ctx context.Context, clubID int64) error {
+// ApproveClub activates the pending club on behalf of actorID, callers must check that the actor is staff.
+func (s Service) ApproveClub(ctx context.Context, clubID, actorID int64) error {
 	const op = "services.management.ApproveClub"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.ApproveClub(ctx, clubID)
+	_, err := s.checkTransition(ctx, clubID, domain.ClubStatusActive)
+	if err != nil {
+		log.Error("failed to check club status", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.ApproveClub(ctx, clubID, actorID, s.roleTemplates, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditClubApproved,
+		Before:  statusPayload(domain.ClubStatusPending),
+		After:   statusPayload(domain.ClubStatusActive),
+	})
 	if err != nil {
+		if errors.Is(err, storage.ErrClubStatusChanged) {
+			log.Error("club status changed concurrently", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrInvalidStatusTransition)
+		}
 		log.Error("failed to approve club", logger.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -58,15 +131,481 @@ func (s Service) ApproveClub(ctx context.Context, clubID int64) error {
 	return nil
 }
 
-func (s Service) RejectClub(ctx context.Context, clubID int64) error {
+// RejectClub rejects the pending club on behalf of actorID with the reason shown to the applicant,
+// callers must check that the actor is staff.
+func (s Service) RejectClub(ctx context.Context, clubID, actorID int64, reason string) error {
 	const op = "services.management.RejectClub"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.RejectClub(ctx, clubID)
+	_, err := s.checkTransition(ctx, clubID, domain.ClubStatusRejected)
+	if err != nil {
+		log.Error("failed to check club status", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.RejectClub(ctx, clubID, actorID, reason, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditClubRejected,
+		Before:  statusPayload(domain.ClubStatusPending),
+		After:   map[string]any{"status": domain.ClubStatusRejected, "reason": reason},
+	})
 	if err != nil {
+		if errors.Is(err, storage.ErrClubStatusChanged) {
+			log.Error("club status changed concurrently", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrInvalidStatusTransition)
+		}
 		log.Error("failed to reject club", logger.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
+
+// ResubmitClub sends the rejected club for review again, optionally with updated details.
+// Only the applicant can resubmit.
+func (s Service) ResubmitClub(ctx context.Context, dto dtos.UpdateClubDTO) error {
+	const op = "services.management.ResubmitClub"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", dto.ClubID))
+
+	club, err := s.storage.GetClubByID(ctx, dto.ClubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if club.OwnerID != dto.UserID {
+		log.Warn("only applicant can resubmit club", slog.Int64("user_id", dto.UserID))
+		return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+	if !club.Status.CanTransitionTo(domain.ClubStatusPending) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidStatusTransition)
+	}
+
+	after := applyUpdate(*club, dto)
+	err = s.storage.ResubmitClub(ctx, dto, domain.AuditEntry{
+		ClubID:  dto.ClubID,
+		ActorID: dto.UserID,
+		Action:  domain.AuditClubResubmitted,
+		Before:  detailsPayload(club),
+		After:   detailsPayload(&after),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrClubStatusChanged) {
+			log.Error("club status changed concurrently", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrInvalidStatusTransition)
+		}
+		log.Error("failed to resubmit club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) UpdateClub(ctx context.Context, dto dtos.UpdateClubDTO) error {
+	const op = "services.management.UpdateClub"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", dto.ClubID))
+
+	if dto.IsEmpty() {
+		return nil
+	}
+
+	before, err := s.storage.GetClubByID(ctx, dto.ClubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	after := applyUpdate(*before, dto)
+	err = s.storage.UpdateClub(ctx, dto, domain.AuditEntry{
+		ClubID:  dto.ClubID,
+		ActorID: dto.UserID,
+		Action:  domain.AuditClubUpdated,
+		Before:  detailsPayload(before),
+		After:   detailsPayload(&after),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to update club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) DeactivateClub(ctx context.Context, clubID, actorID int64) error {
+	const op = "services.management.DeactivateClub"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID))
+
+	err := s.setStatus(ctx, clubID, actorID, domain.ClubStatusDeactivated, domain.AuditClubDeactivated)
+	if err != nil {
+		log.Error("failed to deactivate club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) ReactivateClub(ctx context.Context, clubID, actorID int64) error {
+	const op = "services.management.ReactivateClub"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID))
+
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// Pending clubs can also become active, but only through ApproveClub.
+	if club.Status != domain.ClubStatusDeactivated {
+		log.Error("club is not deactivated", slog.String("status", string(club.Status)))
+		return fmt.Errorf("%s: %w", op, ErrInvalidStatusTransition)
+	}
+
+	err = s.setStatus(ctx, clubID, actorID, domain.ClubStatusActive, domain.AuditClubReactivated)
+	if err != nil {
+		log.Error("failed to reactivate club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) UpdateLogo(ctx context.Context, clubID, actorID int64, logo []byte) (*domain.Club, error) {
+	return s.updateImage(ctx, clubID, actorID, domain.ImageKindLogo, logo)
+}
+
+func (s Service) UpdateBanner(ctx context.Context, clubID, actorID int64, banner []byte) (*domain.Club, error) {
+	return s.updateImage(ctx, clubID, actorID, domain.ImageKindBanner, banner)
+}
+
+func (s Service) updateImage(ctx context.Context, clubID, actorID int64, kind domain.ImageKind, data []byte) (*domain.Club, error) {
+	const op = "services.management.updateImage"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.String("kind", string(kind)))
+
+	img, err := domain.ImageSpecs[kind].Validate(data)
+	if err != nil {
+		log.Warn("invalid image", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := fmt.Sprintf("clubs/%d/%s-%d%s", clubID, kind, time.Now().UnixNano(), img.Extension())
+	url, err := s.fileStorage.SaveFile(ctx, key, img.Data, img.ContentType)
+	if err != nil {
+		log.Error("failed to save image", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.UpdateClubImage(ctx, clubID, kind, url, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditClubImageUpdated,
+		After:   map[string]string{"kind": string(kind), "url": url},
+	})
+	if err != nil {
+		if delErr := s.fileStorage.DeleteFile(ctx, key); delErr != nil {
+			log.Warn("failed to delete orphaned image", logger.Err(delErr))
+		}
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to update club image", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		log.Error("failed to get club", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return club, nil
+}
+
+// TransferOwnership hands the club over to newOwnerID, only the current owner or a platform admin can do it.
+func (s Service) TransferOwnership(ctx context.Context, clubID, actorID, newOwnerID int64, platformRoles []string) error {
+	const op = "services.management.TransferOwnership"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("new_owner_id", newOwnerID))
+
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if club.OwnerID != actorID && !domain.IsPlatformAdmin(platformRoles) {
+		log.Warn("only club owner or platform admin can transfer ownership", slog.Int64("actor_id", actorID))
+		return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+	if club.OwnerID == newOwnerID {
+		return fmt.Errorf("%s: %w", op, ErrAlreadyOwner)
+	}
+
+	err = s.storage.TransferOwnership(ctx, clubID, club.OwnerID, newOwnerID, actorID, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  actorID,
+		TargetID: newOwnerID,
+		Action:   domain.AuditOwnershipTransferred,
+		Before:   map[string]int64{"owner_id": club.OwnerID},
+		After:    map[string]int64{"owner_id": newOwnerID},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrUserNotClubMember):
+			log.Error("new owner is not club member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrNewOwnerNotMember)
+		case errors.Is(err, storage.ErrClubOwnerChanged):
+			log.Error("club owner changed concurrently", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		default:
+			log.Error("failed to transfer ownership", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// SetJoinPolicy changes how users join the active club, the actor needs the ManageClub permission.
+func (s Service) SetJoinPolicy(ctx context.Context, clubID, actorID int64, policy domain.JoinPolicy) error {
+	const op = "services.management.SetJoinPolicy"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.String("join_policy", string(policy)))
+
+	if !policy.IsValid() {
+		return fmt.Errorf("%s: %w", op, ErrInvalidJoinPolicy)
+	}
+
+	err := s.authorize(ctx, clubID, actorID, domain.ManageClub)
+	if err != nil {
+		log.Warn("changing join policy is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if club.JoinPolicy == policy {
+		return nil
+	}
+
+	err = s.storage.SetJoinPolicy(ctx, clubID, policy, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditJoinPolicyChanged,
+		Before:  map[string]domain.JoinPolicy{"join_policy": club.JoinPolicy},
+		After:   map[string]domain.JoinPolicy{"join_policy": policy},
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to set join policy", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetMaxMembers limits the number of members of the active club, nil removes the limit. The actor needs
+// the ManageClub permission. Members over a lowered limit stay, new ones are waitlisted until seats free up.
+func (s Service) SetMaxMembers(ctx context.Context, clubID, actorID int64, maxMembers *int) error {
+	const op = "services.management.SetMaxMembers"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID))
+
+	if maxMembers != nil && *maxMembers < 1 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidMaxMembers)
+	}
+
+	err := s.authorize(ctx, clubID, actorID, domain.ManageClub)
+	if err != nil {
+		log.Warn("changing max members is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to get club", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Zero is no limit, like in domain.Club.
+	limit := 0
+	if maxMembers != nil {
+		limit = *maxMembers
+	}
+	if club.MaxMembers == limit {
+		return nil
+	}
+
+	err = s.storage.SetMaxMembers(ctx, clubID, maxMembers, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditMaxMembersChanged,
+		Before:  maxMembersPayload(club.MaxMembers),
+		After:   maxMembersPayload(limit),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		}
+		log.Error("failed to set max members", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListAuditLog returns the audit entries of the club matching filter, newest first.
+// Only platform admins and club administrators can read the audit log.
+func (s Service) ListAuditLog(
+	ctx context.Context,
+	actorID int64, platformRoles []string,
+	filter domain.AuditFilter, filters domain.Filters,
+) (
+	[]*domain.AuditEntry,
+	*domain.Metadata,
+	error,
+) {
+	const op = "services.management.ListAuditLog"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", filter.ClubID))
+
+	if !domain.IsPlatformAdmin(platformRoles) {
+		err := s.authorize(ctx, filter.ClubID, actorID, domain.Administrator)
+		if err != nil {
+			log.Warn("listing audit log is not allowed", logger.Err(err))
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	entries, metadata, err := s.storage.ListAuditLog(ctx, filter, filters)
+	if err != nil {
+		log.Error("failed to list audit log", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, metadata, nil
+}
+
+// authorize checks that the actor has permission in the club.
+func (s Service) authorize(ctx context.Context, clubID, actorID int64, permission uint64) error {
+	isAuthorized, err := s.permission.HasPermission(ctx, clubID, actorID, permission)
+	if err != nil {
+		if errors.Is(err, accessControl.ErrUserNotClubMember) {
+			return ErrUserNotClubMember
+		}
+		return err
+	}
+	if !isAuthorized {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// checkTransition returns the current status of the club if it is allowed to move to next.
+func (s Service) checkTransition(ctx context.Context, clubID int64, next domain.ClubStatus) (domain.ClubStatus, error) {
+	club, err := s.storage.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, storage.ErrClubNotExists) {
+			return "", ErrClubNotExists
+		}
+		return "", err
+	}
+
+	if !club.Status.CanTransitionTo(next) {
+		return "", ErrInvalidStatusTransition
+	}
+
+	return club.Status, nil
+}
+
+// setStatus moves the club to status next on behalf of actorID and records the change as action.
+func (s Service) setStatus(ctx context.Context, clubID, actorID int64, next domain.ClubStatus, action domain.AuditAction) error {
+	current, err := s.checkTransition(ctx, clubID, next)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.SetClubStatus(ctx, clubID, current, next, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  action,
+		Before:  statusPayload(current),
+		After:   statusPayload(next),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrClubStatusChanged) {
+			return ErrInvalidStatusTransition
+		}
+		return err
+	}
+
+	return nil
+}
+
+func statusPayload(status domain.ClubStatus) map[string]domain.ClubStatus {
+	return map[string]domain.ClubStatus{"status": status}
+}
+
+// maxMembersPayload returns the member limit for the audit log, an unlimited club has null max members.
+func maxMembersPayload(maxMembers int) map[string]*int {
+	if maxMembers == 0 {
+		return map[string]*int{"max_members": nil}
+	}
+	return map[string]*int{"max_members": &maxMembers}
+}
+
+// applyUpdate returns club with the fields set in dto.
+func applyUpdate(club domain.Club, dto dtos.UpdateClubDTO) domain.Club {
+	if dto.Name != nil {
+		club.Name = *dto.Name
+	}
+	if dto.Description != nil {
+		club.Description = *dto.Description
+	}
+	if dto.ClubType != nil {
+		club.ClubType = *dto.ClubType
+	}
+	return club
+}
+
+// detailsPayload returns the editable fields of the club for the audit log.
+func detailsPayload(club *domain.Club) map[string]string {
+	return map[string]string{
+		"name":        club.Name,
+		"description": club.Description,
+		"club_type":   club.ClubType,
+	}
+}
@@ -0,0 +1,392 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+// memoryStorage keeps a single club, methods not used by the tests are left to the embedded nil Storage.
+type memoryStorage struct {
+	Storage
+	club *domain.Club
+	// statusChanged makes SetClubStatus fail as if the status was changed concurrently.
+	statusChanged bool
+	audit         []domain.AuditEntry
+	// members are the users that can become the owner of the club.
+	members []int64
+	// auditFilter is the filter of the last ListAuditLog call.
+	auditFilter *domain.AuditFilter
+}
+
+func (s *memoryStorage) GetClubByID(_ context.Context, clubID int64) (*domain.Club, error) {
+	if s.club == nil || s.club.ID != clubID {
+		return nil, storage.ErrClubNotExists
+	}
+	club := *s.club
+	return &club, nil
+}
+
+func (s *memoryStorage) SetClubStatus(_ context.Context, _ int64, from, to domain.ClubStatus, audit domain.AuditEntry) error {
+	if s.statusChanged || s.club.Status != from {
+		return storage.ErrClubStatusChanged
+	}
+	s.club.Status = to
+	s.audit = append(s.audit, audit)
+	return nil
+}
+
+func (s *memoryStorage) SetJoinPolicy(_ context.Context, _ int64, policy domain.JoinPolicy, audit domain.AuditEntry) error {
+	s.club.JoinPolicy = policy
+	s.audit = append(s.audit, audit)
+	return nil
+}
+
+func (s *memoryStorage) SetMaxMembers(_ context.Context, _ int64, maxMembers *int, audit domain.AuditEntry) error {
+	s.club.MaxMembers = 0
+	if maxMembers != nil {
+		s.club.MaxMembers = *maxMembers
+	}
+	s.audit = append(s.audit, audit)
+	return nil
+}
+
+func (s *memoryStorage) ListAuditLog(_ context.Context, filter domain.AuditFilter, _ domain.Filters) (
+	[]*domain.AuditEntry,
+	*domain.Metadata,
+	error,
+) {
+	s.auditFilter = &filter
+	entries := make([]*domain.AuditEntry, len(s.audit))
+	for i := range s.audit {
+		entries[i] = &s.audit[i]
+	}
+	return entries, &domain.Metadata{}, nil
+}
+
+func (s *memoryStorage) TransferOwnership(_ context.Context, _, fromUserID, toUserID, _ int64, audit domain.AuditEntry) error {
+	if s.club.OwnerID != fromUserID {
+		return storage.ErrClubOwnerChanged
+	}
+	if !slices.Contains(s.members, toUserID) {
+		return storage.ErrUserNotClubMember
+	}
+	s.club.OwnerID = toUserID
+	s.audit = append(s.audit, audit)
+	return nil
+}
+
+// memoryPermissions grants the permissions of every user in the map, other users are not club members.
+type memoryPermissions map[int64]uint64
+
+func (p memoryPermissions) HasPermission(_ context.Context, _, userID int64, permission uint64) (bool, error) {
+	permissions, ok := p[userID]
+	if !ok {
+		return false, accessControl.ErrUserNotClubMember
+	}
+	return domain.HasPermission(permissions, permission), nil
+}
+
+const (
+	managerID = 10
+	memberID  = 11
+	adminID   = 12
+)
+
+func newTestService(storage Storage) *Service {
+	return New(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		storage,
+		nil,
+		memoryPermissions{managerID: domain.ManageClub, memberID: domain.ManageMembership, adminID: domain.Administrator},
+		nil,
+	)
+}
+
+func TestService_SetStatus(t *testing.T) {
+	const clubID, actorID = 1, 2
+
+	tests := []struct {
+		name          string
+		status        domain.ClubStatus
+		exists        bool
+		statusChanged bool
+		reactivate    bool
+		want          domain.ClubStatus
+		wantAction    domain.AuditAction
+		wantErr       error
+	}{
+		{
+			name: "Deactivate active club", status: domain.ClubStatusActive, exists: true,
+			want: domain.ClubStatusDeactivated, wantAction: domain.AuditClubDeactivated,
+		},
+		{
+			name: "Deactivate pending club", status: domain.ClubStatusPending, exists: true,
+			want: domain.ClubStatusPending, wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "Deactivate deactivated club", status: domain.ClubStatusDeactivated, exists: true,
+			want: domain.ClubStatusDeactivated, wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "Deactivate club changed concurrently", status: domain.ClubStatusActive, exists: true, statusChanged: true,
+			want: domain.ClubStatusActive, wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "Deactivate missing club", wantErr: ErrClubNotExists,
+		},
+		{
+			name: "Reactivate deactivated club", status: domain.ClubStatusDeactivated, exists: true, reactivate: true,
+			want: domain.ClubStatusActive, wantAction: domain.AuditClubReactivated,
+		},
+		{
+			name: "Reactivate pending club", status: domain.ClubStatusPending, exists: true, reactivate: true,
+			want: domain.ClubStatusPending, wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "Reactivate active club", status: domain.ClubStatusActive, exists: true, reactivate: true,
+			want: domain.ClubStatusActive, wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name: "Reactivate missing club", reactivate: true, wantErr: ErrClubNotExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{statusChanged: tt.statusChanged}
+			if tt.exists {
+				memory.club = &domain.Club{ID: clubID, Status: tt.status}
+			}
+			service := newTestService(memory)
+
+			var err error
+			if tt.reactivate {
+				err = service.ReactivateClub(context.Background(), clubID, actorID)
+			} else {
+				err = service.DeactivateClub(context.Background(), clubID, actorID)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !tt.exists {
+				return
+			}
+
+			if memory.club.Status != tt.want {
+				t.Errorf("status = %v, want %v", memory.club.Status, tt.want)
+			}
+			if tt.wantErr != nil {
+				if len(memory.audit) != 0 {
+					t.Errorf("recorded %d audit entries, want none", len(memory.audit))
+				}
+				return
+			}
+			if len(memory.audit) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(memory.audit))
+			}
+			entry := memory.audit[0]
+			if entry.Action != tt.wantAction || entry.ActorID != actorID || entry.ClubID != clubID {
+				t.Errorf("audit entry = %+v, want action %v by %d in club %d", entry, tt.wantAction, actorID, clubID)
+			}
+		})
+	}
+}
+
+func TestService_SetJoinPolicy(t *testing.T) {
+	const clubID = 1
+
+	tests := []struct {
+		name      string
+		actorID   int64
+		policy    domain.JoinPolicy
+		want      domain.JoinPolicy
+		wantAudit bool
+		wantErr   error
+	}{
+		{"Open the club", managerID, domain.JoinPolicyOpen, domain.JoinPolicyOpen, true, nil},
+		{"Make the club invite only", managerID, domain.JoinPolicyInviteOnly, domain.JoinPolicyInviteOnly, true, nil},
+		{"Same policy", managerID, domain.JoinPolicyApproval, domain.JoinPolicyApproval, false, nil},
+		{"Unknown policy", managerID, domain.JoinPolicy("lottery"), domain.JoinPolicyApproval, false, ErrInvalidJoinPolicy},
+		{"Without ManageClub", memberID, domain.JoinPolicyOpen, domain.JoinPolicyApproval, false, ErrPermissionDenied},
+		{"Actor is not member", 99, domain.JoinPolicyOpen, domain.JoinPolicyApproval, false, ErrUserNotClubMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{club: &domain.Club{ID: clubID, Status: domain.ClubStatusActive, JoinPolicy: domain.JoinPolicyApproval}}
+
+			err := newTestService(memory).SetJoinPolicy(context.Background(), clubID, tt.actorID, tt.policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetJoinPolicy() error = %v, want %v", err, tt.wantErr)
+			}
+			if memory.club.JoinPolicy != tt.want {
+				t.Errorf("join policy = %v, want %v", memory.club.JoinPolicy, tt.want)
+			}
+			if !tt.wantAudit {
+				if len(memory.audit) != 0 {
+					t.Errorf("recorded %d audit entries, want none", len(memory.audit))
+				}
+				return
+			}
+			if len(memory.audit) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(memory.audit))
+			}
+			entry := memory.audit[0]
+			if entry.Action != domain.AuditJoinPolicyChanged || entry.ActorID != tt.actorID || entry.ClubID != clubID {
+				t.Errorf("audit entry = %+v, want join policy change by %d in club %d", entry, tt.actorID, clubID)
+			}
+		})
+	}
+}
+
+func TestService_SetMaxMembers(t *testing.T) {
+	const clubID = 1
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name       string
+		actorID    int64
+		current    int
+		maxMembers *int
+		want       int
+		wantAudit  bool
+		wantErr    error
+	}{
+		{"Limit unlimited club", managerID, 0, limit(50), 50, true, nil},
+		{"Raise limit", managerID, 10, limit(20), 20, true, nil},
+		{"Lower limit", managerID, 20, limit(10), 10, true, nil},
+		{"Remove limit", managerID, 10, nil, 0, true, nil},
+		{"Same limit", managerID, 10, limit(10), 10, false, nil},
+		{"Zero", managerID, 10, limit(0), 10, false, ErrInvalidMaxMembers},
+		{"Negative", managerID, 10, limit(-5), 10, false, ErrInvalidMaxMembers},
+		{"Without ManageClub", memberID, 10, limit(20), 10, false, ErrPermissionDenied},
+		{"Actor is not member", 99, 10, limit(20), 10, false, ErrUserNotClubMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{club: &domain.Club{ID: clubID, Status: domain.ClubStatusActive, MaxMembers: tt.current}}
+
+			err := newTestService(memory).SetMaxMembers(context.Background(), clubID, tt.actorID, tt.maxMembers)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetMaxMembers() error = %v, want %v", err, tt.wantErr)
+			}
+			if memory.club.MaxMembers != tt.want {
+				t.Errorf("max members = %d, want %d", memory.club.MaxMembers, tt.want)
+			}
+			if !tt.wantAudit {
+				if len(memory.audit) != 0 {
+					t.Errorf("recorded %d audit entries, want none", len(memory.audit))
+				}
+				return
+			}
+			if len(memory.audit) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(memory.audit))
+			}
+			entry := memory.audit[0]
+			if entry.Action != domain.AuditMaxMembersChanged || entry.ActorID != tt.actorID || entry.ClubID != clubID {
+				t.Errorf("audit entry = %+v, want max members change by %d in club %d", entry, tt.actorID, clubID)
+			}
+		})
+	}
+}
+
+func TestService_ListAuditLog(t *testing.T) {
+	const clubID = 1
+
+	tests := []struct {
+		name          string
+		actorID       int64
+		platformRoles []string
+		wantErr       error
+	}{
+		{"Club administrator", adminID, nil, nil},
+		{"Platform admin outside the club", 99, []string{domain.PlatformRoleAdmin}, nil},
+		{"Platform moderator outside the club", 99, []string{domain.PlatformRoleModerator}, ErrUserNotClubMember},
+		{"Without Administrator", managerID, nil, ErrPermissionDenied},
+		{"Actor is not member", 99, nil, ErrUserNotClubMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{audit: []domain.AuditEntry{{ClubID: clubID, ActorID: managerID, Action: domain.AuditClubUpdated}}}
+			filter := domain.AuditFilter{ClubID: clubID, ActorID: managerID, Action: domain.AuditClubUpdated}
+
+			entries, _, err := newTestService(memory).ListAuditLog(
+				context.Background(), tt.actorID, tt.platformRoles, filter, domain.Filters{Page: 1, PageSize: 10},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListAuditLog() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if memory.auditFilter != nil {
+					t.Error("audit log was read without permission")
+				}
+				return
+			}
+			if memory.auditFilter == nil || *memory.auditFilter != filter {
+				t.Errorf("audit log filter = %+v, want %+v", memory.auditFilter, filter)
+			}
+			if len(entries) != 1 {
+				t.Errorf("got %d entries, want 1", len(entries))
+			}
+		})
+	}
+}
+
+func TestService_TransferOwnership(t *testing.T) {
+	const clubID, ownerID = 1, 20
+
+	tests := []struct {
+		name          string
+		actorID       int64
+		platformRoles []string
+		newOwnerID    int64
+		wantOwner     int64
+		wantErr       error
+	}{
+		{"Owner hands over to member", ownerID, nil, memberID, memberID, nil},
+		{"Platform admin hands over to member", 99, []string{domain.PlatformRoleAdmin}, memberID, memberID, nil},
+		{"Club manager is not owner", managerID, nil, memberID, ownerID, ErrPermissionDenied},
+		{"Platform moderator", 99, []string{domain.PlatformRoleModerator}, memberID, ownerID, ErrPermissionDenied},
+		{"New owner is already owner", ownerID, nil, ownerID, ownerID, ErrAlreadyOwner},
+		{"New owner is not member", ownerID, nil, 99, ownerID, ErrNewOwnerNotMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{
+				club:    &domain.Club{ID: clubID, OwnerID: ownerID, Status: domain.ClubStatusActive},
+				members: []int64{ownerID, managerID, memberID},
+			}
+
+			err := newTestService(memory).TransferOwnership(context.Background(), clubID, tt.actorID, tt.newOwnerID, tt.platformRoles)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransferOwnership() error = %v, want %v", err, tt.wantErr)
+			}
+			if memory.club.OwnerID != tt.wantOwner {
+				t.Errorf("owner = %d, want %d", memory.club.OwnerID, tt.wantOwner)
+			}
+			if tt.wantErr != nil {
+				if len(memory.audit) != 0 {
+					t.Errorf("recorded %d audit entries, want none", len(memory.audit))
+				}
+				return
+			}
+			if len(memory.audit) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(memory.audit))
+			}
+			entry := memory.audit[0]
+			if entry.Action != domain.AuditOwnershipTransferred || entry.ActorID != tt.actorID || entry.TargetID != tt.newOwnerID {
+				t.Errorf("audit entry = %+v, want ownership transfer to %d by %d", entry, tt.newOwnerID, tt.actorID)
+			}
+		})
+	}
+}
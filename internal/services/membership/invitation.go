@@ -0,0 +1,173 @@
+package membership
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
+	"log/slog"
+	"time"
+)
+
+// invitationCodeBytes is the entropy of generated invitation codes.
+const invitationCodeBytes = 12
+
+var (
+	ErrInvitationNotExists = errors.New("invitation does not exists")
+	ErrInvitationNotValid  = errors.New("invitation is revoked, expired or used up")
+	ErrInvalidInvitation   = errors.New("invitation max uses or expiry is not valid")
+	ErrInviteeNotExists    = errors.New("invited user does not exists")
+)
+
+// CreateInvitation creates an invitation to the club on behalf of invitation.CreatedBy and returns it
+// with the generated code. A direct invitation to invitation.InviteeID can be redeemed once.
+func (s Service) CreateInvitation(ctx context.Context, invitation domain.Invitation) (*domain.Invitation, error) {
+	const op = "services.membership.CreateInvitation"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", invitation.ClubID))
+
+	err := s.authorize(ctx, invitation.ClubID, invitation.CreatedBy, domain.ManageMembership)
+	if err != nil {
+		log.Warn("inviting is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if invitation.IsDirect() {
+		if invitation.InviteeID == invitation.CreatedBy {
+			return nil, fmt.Errorf("%s: %w", op, ErrCannotActOnSelf)
+		}
+		invitation.MaxUses = 1
+	}
+	if invitation.MaxUses < 0 || (invitation.ExpiresAt != nil && !invitation.ExpiresAt.After(time.Now())) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidInvitation)
+	}
+
+	invitation.Code, err = newInvitationCode()
+	if err != nil {
+		log.Error("failed to generate invitation code", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := s.storage.SaveInvitation(ctx, invitation)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrClubNotActive):
+			log.Error("club is not active", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubNotActive)
+		case errors.Is(err, storage.ErrUserIsClubMember):
+			log.Warn("invited user is already club member", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrUserIsClubMember)
+		case errors.Is(err, storage.ErrUserNotExists):
+			log.Warn("invited user does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrInviteeNotExists)
+		default:
+			log.Error("failed to save invitation", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return saved, nil
+}
+
+func (s Service) ListInvitations(ctx context.Context, clubID, actorID int64, filters domain.Filters) (
+	[]*domain.Invitation,
+	*domain.Metadata,
+	error,
+) {
+	const op = "services.membership.ListInvitations"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID))
+
+	err := s.authorize(ctx, clubID, actorID, domain.ManageMembership)
+	if err != nil {
+		log.Warn("listing invitations is not allowed", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invitations, metadata, err := s.storage.ListInvitations(ctx, clubID, filters)
+	if err != nil {
+		log.Error("failed to list invitations", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invitations, metadata, nil
+}
+
+func (s Service) RevokeInvitation(ctx context.Context, clubID, actorID, invitationID int64) error {
+	const op = "services.membership.RevokeInvitation"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("invitation_id", invitationID))
+
+	err := s.authorize(ctx, clubID, actorID, domain.ManageMembership)
+	if err != nil {
+		log.Warn("revoking invitation is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.RevokeInvitation(ctx, clubID, invitationID, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditInvitationRevoked,
+		After:   map[string]any{"invitation_id": invitationID},
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrInvitationNotExists) {
+			log.Warn("invitation does not exists or is already revoked", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrInvitationNotExists)
+		}
+		log.Error("failed to revoke invitation", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RedeemInvitation makes the user a member of the club of the invitation with code and returns the invitation.
+func (s Service) RedeemInvitation(ctx context.Context, userID int64, code string) (*domain.Invitation, error) {
+	const op = "services.membership.RedeemInvitation"
+	log := s.log.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	invitation, err := s.storage.RedeemInvitation(ctx, code, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvitationNotExists):
+			log.Warn("invitation does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrInvitationNotExists)
+		case errors.Is(err, storage.ErrInvitationNotValid):
+			log.Warn("invitation is not valid", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrInvitationNotValid)
+		case errors.Is(err, storage.ErrClubNotActive):
+			log.Warn("club is not active", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubNotActive)
+		case errors.Is(err, storage.ErrClubClosed):
+			log.Warn("club is closed", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubClosed)
+		case errors.Is(err, storage.ErrClubFull):
+			log.Warn("club is full", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrClubFull)
+		case errors.Is(err, storage.ErrUserBanned):
+			log.Warn("banned user tried to redeem invitation", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrUserBanned)
+		case errors.Is(err, storage.ErrUserIsClubMember):
+			log.Warn("member tried to redeem invitation", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrUserIsClubMember)
+		default:
+			log.Error("failed to redeem invitation", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return invitation, nil
+}
+
+func newInvitationCode() (string, error) {
+	b := make([]byte, invitationCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
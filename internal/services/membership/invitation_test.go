@@ -0,0 +1,139 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"testing"
+	"time"
+)
+
+func (s *memoryStorage) SaveInvitation(_ context.Context, invitation domain.Invitation) (*domain.Invitation, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &invitation, nil
+}
+
+func (s *memoryStorage) RedeemInvitation(_ context.Context, code string, _ int64) (*domain.Invitation, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.Invitation{ClubID: testClubID, Code: code}, nil
+}
+
+func TestService_CreateInvitation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		invitation  domain.Invitation
+		storageErr  error
+		wantMaxUses int
+		wantErr     error
+	}{
+		{
+			name:        "Shareable invitation",
+			invitation:  domain.Invitation{CreatedBy: reviewerID, MaxUses: 10, ExpiresAt: &future},
+			wantMaxUses: 10,
+		},
+		{
+			name:        "Unlimited invitation",
+			invitation:  domain.Invitation{CreatedBy: reviewerID},
+			wantMaxUses: 0,
+		},
+		{
+			name:        "Direct invitation is redeemable once",
+			invitation:  domain.Invitation{CreatedBy: reviewerID, InviteeID: applicant, MaxUses: 5},
+			wantMaxUses: 1,
+		},
+		{
+			name:       "Direct invitation to self",
+			invitation: domain.Invitation{CreatedBy: reviewerID, InviteeID: reviewerID},
+			wantErr:    ErrCannotActOnSelf,
+		},
+		{
+			name:       "Negative max uses",
+			invitation: domain.Invitation{CreatedBy: reviewerID, MaxUses: -1},
+			wantErr:    ErrInvalidInvitation,
+		},
+		{
+			name:       "Already expired",
+			invitation: domain.Invitation{CreatedBy: reviewerID, ExpiresAt: &past},
+			wantErr:    ErrInvalidInvitation,
+		},
+		{
+			name:       "Without ManageMembership",
+			invitation: domain.Invitation{CreatedBy: applicant},
+			wantErr:    ErrPermissionDenied,
+		},
+		{
+			name:       "Invitee is already member",
+			invitation: domain.Invitation{CreatedBy: reviewerID, InviteeID: applicant},
+			storageErr: storage.ErrUserIsClubMember,
+			wantErr:    ErrUserIsClubMember,
+		},
+		{
+			name:       "Invitee does not exist",
+			invitation: domain.Invitation{CreatedBy: reviewerID, InviteeID: applicant},
+			storageErr: storage.ErrUserNotExists,
+			wantErr:    ErrInviteeNotExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{err: tt.storageErr}
+			tt.invitation.ClubID = testClubID
+
+			saved, err := newTestService(memory).CreateInvitation(context.Background(), tt.invitation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateInvitation() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if saved.Code == "" {
+				t.Error("invitation code is empty")
+			}
+			if saved.MaxUses != tt.wantMaxUses {
+				t.Errorf("MaxUses = %d, want %d", saved.MaxUses, tt.wantMaxUses)
+			}
+		})
+	}
+}
+
+func TestService_RedeemInvitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		storageErr error
+		wantErr    error
+	}{
+		{"Redeemed", nil, nil},
+		{"Unknown code", storage.ErrInvitationNotExists, ErrInvitationNotExists},
+		{"Revoked, expired or used up", storage.ErrInvitationNotValid, ErrInvitationNotValid},
+		{"Inactive club", storage.ErrClubNotActive, ErrClubNotActive},
+		{"Closed club", storage.ErrClubClosed, ErrClubClosed},
+		{"Full club", storage.ErrClubFull, ErrClubFull},
+		{"Banned user", storage.ErrUserBanned, ErrUserBanned},
+		{"Member", storage.ErrUserIsClubMember, ErrUserIsClubMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{err: tt.storageErr}
+
+			invitation, err := newTestService(memory).RedeemInvitation(context.Background(), applicant, "code")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RedeemInvitation() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && invitation.ClubID != testClubID {
+				t.Errorf("invitation club = %d, want %d", invitation.ClubID, testClubID)
+			}
+		})
+	}
+}
@@ -2,35 +2,117 @@ package membership
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
 	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
 	"log/slog"
+	"time"
+)
+
+var (
+	ErrClubNotActive        = errors.New("club is not active")
+	ErrClubNotExists        = errors.New("club does not exists")
+	ErrUserNotClubMember    = errors.New("user is not club member")
+	ErrUserIsClubOwner      = errors.New("club owner can not leave the club")
+	ErrTargetIsClubOwner    = errors.New("club owner can not be kicked or banned")
+	ErrTargetNotMember      = errors.New("target user is not club member")
+	ErrCannotActOnSelf      = errors.New("user can not act on themselves")
+	ErrPermissionDenied     = errors.New("user does not have permission")
+	ErrUserBanned           = errors.New("user is banned from club")
+	ErrBanNotExists         = errors.New("ban does not exists")
+	ErrUserIsClubMember     = errors.New("user is already club member")
+	ErrJoinRequestExists    = errors.New("pending join request already exists")
+	ErrJoinRequestNotExists = errors.New("pending join request does not exists")
+	ErrClubInviteOnly       = errors.New("club accepts members by invitation only")
+	ErrClubClosed           = errors.New("club does not accept new members")
+	ErrClubFull             = errors.New("club has reached max members")
+	ErrInvalidDecision      = errors.New("join request can only be approved or rejected")
+	ErrTooManyJoinRequests  = errors.New("too many join requests in a single call")
 )
 
 type Service struct {
-	log     *slog.Logger
-	storage Storage
+	log        *slog.Logger
+	storage    Storage
+	permission PermissionChecker
 }
 
 type Storage interface {
-	InsertJoinRequest(ctx context.Context, userID, clubID int64) error
-	AddNewMember(ctx context.Context, clubID, userID int64) error
-	DeleteJoinRequest(ctx context.Context, clubID, userID int64) error
+	InsertJoinRequest(ctx context.Context, userID, clubID int64, message string) (domain.JoinRequestStatus, error)
+	AddNewMember(ctx context.Context, clubID, userID, reviewerID int64) (domain.JoinRequestStatus, error)
+	RejectJoinRequest(ctx context.Context, clubID, userID, reviewerID int64, note string) error
+	WithdrawJoinRequest(ctx context.Context, clubID, userID int64) error
+	DecideJoinRequests(
+		ctx context.Context,
+		clubID, reviewerID int64,
+		userIDs []int64,
+		status domain.JoinRequestStatus,
+		note string,
+	) ([]domain.JoinRequestDecision, error)
+	DeleteMember(ctx context.Context, clubID, userID int64, event domain.Event, audit domain.AuditEntry) error
+	BanMember(ctx context.Context, ban domain.Ban, audit domain.AuditEntry) error
+	ListBans(ctx context.Context, clubID int64, filters domain.Filters) ([]*domain.Ban, *domain.Metadata, error)
+	DeleteBan(ctx context.Context, clubID, userID int64, audit domain.AuditEntry) error
+	SaveInvitation(ctx context.Context, invitation domain.Invitation) (*domain.Invitation, error)
+	ListInvitations(ctx context.Context, clubID int64, filters domain.Filters) (
+		[]*domain.Invitation,
+		*domain.Metadata,
+		error,
+	)
+	RevokeInvitation(ctx context.Context, clubID, invitationID int64, audit domain.AuditEntry) error
+	RedeemInvitation(ctx context.Context, code string, userID int64) (*domain.Invitation, error)
+}
+
+type PermissionChecker interface {
+	CanActOnMember(ctx context.Context, clubID, userID, targetID int64, permission uint64) (bool, error)
+	HasPermission(ctx context.Context, clubID, userID int64, permission uint64) (bool, error)
 }
 
-func New(log *slog.Logger, storage Storage) *Service {
+func New(
+	log *slog.Logger,
+	storage Storage,
+	permission PermissionChecker,
+) *Service {
 	return &Service{
-		log:     log,
-		storage: storage,
+		log:        log,
+		storage:    storage,
+		permission: permission,
 	}
 }
 
-func (s Service) CreateJoinRequest(ctx context.Context, userID, clubID int64) error {
+// CreateJoinRequest creates a pending join request of the user with an optional message to the reviewers.
+// The request is approved right away if the club is open, or waitlisted if the open club is full.
+func (s Service) CreateJoinRequest(ctx context.Context, userID, clubID int64, message string) error {
 	const op = "services.membership.CreateJoinRequest"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.InsertJoinRequest(ctx, userID, clubID)
+	_, err := s.storage.InsertJoinRequest(ctx, userID, clubID, message)
 	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrClubNotActive):
+			log.Error("club is not active", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotActive)
+		case errors.Is(err, storage.ErrUserBanned):
+			log.Warn("banned user tried to join club", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrUserBanned)
+		case errors.Is(err, storage.ErrUserIsClubMember):
+			log.Warn("member tried to join club", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrUserIsClubMember)
+		case errors.Is(err, storage.ErrJoinRequestExists):
+			log.Warn("user already has pending join request", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrJoinRequestExists)
+		case errors.Is(err, storage.ErrClubInviteOnly):
+			log.Warn("user tried to join invite-only club", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubInviteOnly)
+		case errors.Is(err, storage.ErrClubClosed):
+			log.Warn("user tried to join closed club", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubClosed)
+		}
 		log.Error("failed to create new join request", logger.Err(err))
 		return err
 	}
@@ -38,28 +120,340 @@ func (s Service) CreateJoinRequest(ctx context.Context, userID, clubID int64) er
 	return nil
 }
 
-func (s Service) ApproveMembership(ctx context.Context, clubID, userID int64) error {
+// ApproveMembership approves the join request of the user, it is waitlisted if the club is full.
+func (s Service) ApproveMembership(ctx context.Context, clubID, actorID, userID int64) error {
 	const op = "services.membership.ApproveMembership"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.AddNewMember(ctx, clubID, userID)
+	status, err := s.storage.AddNewMember(ctx, clubID, userID, actorID)
 	if err != nil {
-		log.Error("failed to add not member to club", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		switch {
+		case errors.Is(err, storage.ErrJoinRequestNotExists):
+			log.Warn("join request is not pending", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrJoinRequestNotExists)
+		case errors.Is(err, storage.ErrClubNotActive):
+			log.Warn("club is not active", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotActive)
+		default:
+			log.Error("failed to add not member to club", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if status == domain.JoinRequestStatusWaitlisted {
+		log.Info("club is full, join request is waitlisted", slog.Int64("user_id", userID))
 	}
 
 	return nil
 }
 
-func (s Service) RejectMembership(ctx context.Context, clubID, userID int64) error {
+// RejectMembership rejects the pending join request of the user, the note is shown to the applicant.
+func (s Service) RejectMembership(ctx context.Context, clubID, actorID, userID int64, note string) error {
 	const op = "services.membership.RejectMembership"
 	log := s.log.With(slog.String("op", op))
 
-	err := s.storage.DeleteJoinRequest(ctx, clubID, userID)
+	err := s.storage.RejectJoinRequest(ctx, clubID, userID, actorID, note)
+	if err != nil {
+		if errors.Is(err, storage.ErrJoinRequestNotExists) {
+			log.Warn("join request is not pending", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrJoinRequestNotExists)
+		}
+		log.Error("failed to reject join request", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// maxBulkJoinRequests limits the number of join requests decided by HandleJoinRequests at once.
+const maxBulkJoinRequests = 500
+
+// HandleJoinRequests approves or rejects the pending join requests of userIDs with a single permission check
+// and returns the outcome for every distinct user in the given order. Join requests that are not pending
+// fail with ErrJoinRequestNotExists without affecting the rest, approvals beyond the max members are waitlisted.
+func (s Service) HandleJoinRequests(
+	ctx context.Context,
+	clubID, actorID int64,
+	userIDs []int64,
+	decision domain.JoinRequestStatus,
+	note string,
+) ([]domain.JoinRequestDecision, error) {
+	const op = "services.membership.HandleJoinRequests"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.String("decision", string(decision)))
+
+	if decision != domain.JoinRequestStatusApproved && decision != domain.JoinRequestStatusRejected {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDecision)
+	}
+
+	userIDs = uniqueIDs(userIDs)
+	if len(userIDs) > maxBulkJoinRequests {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooManyJoinRequests)
+	}
+	if len(userIDs) == 0 {
+		return []domain.JoinRequestDecision{}, nil
+	}
+
+	err := s.authorize(ctx, clubID, actorID, domain.ManageMembership)
+	if err != nil {
+		log.Warn("handling join requests is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	decisions, err := s.storage.DecideJoinRequests(ctx, clubID, actorID, userIDs, decision, note)
+	if err != nil {
+		log.Error("failed to decide join requests", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, d := range decisions {
+		switch {
+		case d.Err == nil:
+			continue
+		case errors.Is(d.Err, storage.ErrJoinRequestNotExists):
+			decisions[i].Err = ErrJoinRequestNotExists
+		case errors.Is(d.Err, storage.ErrClubNotActive):
+			decisions[i].Err = ErrClubNotActive
+		default:
+			log.Error("failed to decide join request", slog.Int64("user_id", d.UserID), logger.Err(d.Err))
+			decisions[i].Err = fmt.Errorf("%s: %w", op, d.Err)
+		}
+	}
+
+	return decisions, nil
+}
+
+// WithdrawJoinRequest withdraws the pending join request of the user.
+func (s Service) WithdrawJoinRequest(ctx context.Context, clubID, userID int64) error {
+	const op = "services.membership.WithdrawJoinRequest"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("user_id", userID))
+
+	err := s.storage.WithdrawJoinRequest(ctx, clubID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrJoinRequestNotExists) {
+			log.Warn("join request is not pending", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrJoinRequestNotExists)
+		}
+		log.Error("failed to withdraw join request", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) LeaveClub(ctx context.Context, clubID, userID int64) error {
+	const op = "services.membership.LeaveClub"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("user_id", userID))
+
+	err := s.storage.DeleteMember(ctx, clubID, userID, domain.Event{
+		RoutingKey: domain.EventMemberLeft,
+		Payload:    domain.MemberEvent{ClubID: clubID, UserID: userID, OccurredAt: time.Now().UTC()},
+	}, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  userID,
+		TargetID: userID,
+		Action:   domain.AuditMemberLeft,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrUserNotClubMember):
+			log.Error("user is not club member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrUserNotClubMember)
+		case errors.Is(err, storage.ErrUserIsClubOwner):
+			log.Error("club owner tried to leave", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrUserIsClubOwner)
+		default:
+			log.Error("failed to delete member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Service) KickMember(ctx context.Context, clubID, actorID, targetID int64) error {
+	const op = "services.membership.KickMember"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("target_id", targetID))
+
+	err := s.authorizeOnMember(ctx, clubID, actorID, targetID, domain.KickMember)
+	if err != nil {
+		log.Warn("kick is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.DeleteMember(ctx, clubID, targetID, domain.Event{
+		RoutingKey: domain.EventMemberKicked,
+		Payload: domain.MemberEvent{
+			ClubID:     clubID,
+			UserID:     targetID,
+			ActorID:    actorID,
+			OccurredAt: time.Now().UTC(),
+		},
+	}, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  actorID,
+		TargetID: targetID,
+		Action:   domain.AuditMemberKicked,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrUserNotClubMember):
+			log.Error("target is not club member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrTargetNotMember)
+		case errors.Is(err, storage.ErrUserIsClubOwner):
+			log.Error("tried to kick club owner", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrTargetIsClubOwner)
+		default:
+			log.Error("failed to delete member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// BanMember bans ban.UserID from the club on behalf of ban.BannedBy.
+// The target does not have to be a member, so that users can be banned before they join.
+func (s Service) BanMember(ctx context.Context, ban domain.Ban) error {
+	const op = "services.membership.BanMember"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", ban.ClubID), slog.Int64("target_id", ban.UserID))
+
+	err := s.authorizeOnMember(ctx, ban.ClubID, ban.BannedBy, ban.UserID, domain.BanMember)
+	if errors.Is(err, ErrTargetNotMember) {
+		err = s.authorize(ctx, ban.ClubID, ban.BannedBy, domain.BanMember)
+	}
 	if err != nil {
-		log.Error("failed to delete join request", logger.Err(err))
+		log.Warn("ban is not allowed", logger.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = s.storage.BanMember(ctx, ban, domain.AuditEntry{
+		ClubID:   ban.ClubID,
+		ActorID:  ban.BannedBy,
+		TargetID: ban.UserID,
+		Action:   domain.AuditMemberBanned,
+		After:    map[string]any{"reason": ban.Reason, "expires_at": ban.ExpiresAt},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrClubNotExists):
+			log.Error("club does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrClubNotExists)
+		case errors.Is(err, storage.ErrUserIsClubOwner):
+			log.Error("tried to ban club owner", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrTargetIsClubOwner)
+		default:
+			log.Error("failed to ban member", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	return nil
 }
+
+func (s Service) ListBans(ctx context.Context, clubID, actorID int64, filters domain.Filters) ([]*domain.Ban, *domain.Metadata, error) {
+	const op = "services.membership.ListBans"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID))
+
+	err := s.authorize(ctx, clubID, actorID, domain.BanMember)
+	if err != nil {
+		log.Warn("listing bans is not allowed", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bans, metadata, err := s.storage.ListBans(ctx, clubID, filters)
+	if err != nil {
+		log.Error("failed to list bans", logger.Err(err))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bans, metadata, nil
+}
+
+func (s Service) UnbanMember(ctx context.Context, clubID, actorID, targetID int64) error {
+	const op = "services.membership.UnbanMember"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int64("target_id", targetID))
+
+	err := s.authorize(ctx, clubID, actorID, domain.BanMember)
+	if err != nil {
+		log.Warn("unban is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.DeleteBan(ctx, clubID, targetID, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  actorID,
+		TargetID: targetID,
+		Action:   domain.AuditMemberUnbanned,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrBanNotExists) {
+			log.Error("ban does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrBanNotExists)
+		}
+		log.Error("failed to delete ban", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// authorize checks that the actor has permission in the club.
+func (s Service) authorize(ctx context.Context, clubID, actorID int64, permission uint64) error {
+	isAuthorized, err := s.permission.HasPermission(ctx, clubID, actorID, permission)
+	if err != nil {
+		if errors.Is(err, accessControl.ErrUserNotClubMember) {
+			return ErrUserNotClubMember
+		}
+		return err
+	}
+	if !isAuthorized {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// authorizeOnMember checks that the actor has permission and a higher role than the target member.
+func (s Service) authorizeOnMember(ctx context.Context, clubID, actorID, targetID int64, permission uint64) error {
+	if actorID == targetID {
+		return ErrCannotActOnSelf
+	}
+
+	isAuthorized, err := s.permission.CanActOnMember(ctx, clubID, actorID, targetID, permission)
+	if err != nil {
+		switch {
+		case errors.Is(err, accessControl.ErrUserNotClubMember):
+			return ErrUserNotClubMember
+		case errors.Is(err, accessControl.ErrTargetNotClubMember):
+			return ErrTargetNotMember
+		case errors.Is(err, accessControl.ErrInsufficientRolePosition):
+			return ErrPermissionDenied
+		default:
+			return err
+		}
+	}
+	if !isAuthorized {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence of each.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]struct{}, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,250 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+const (
+	testClubID = 1
+	reviewerID = 2
+	applicant  = 3
+)
+
+// memoryStorage answers storage calls with the configured results, methods not used by the tests
+// are left to the embedded nil Storage.
+type memoryStorage struct {
+	Storage
+	err    error
+	status domain.JoinRequestStatus
+	calls  int
+	// itemErrs fails the decisions on the join requests of the users in bulk.
+	itemErrs map[int64]error
+	decided  []int64
+}
+
+func (s *memoryStorage) InsertJoinRequest(_ context.Context, _, _ int64, _ string) (domain.JoinRequestStatus, error) {
+	s.calls++
+	return s.status, s.err
+}
+
+func (s *memoryStorage) DecideJoinRequests(
+	_ context.Context,
+	_, _ int64,
+	userIDs []int64,
+	status domain.JoinRequestStatus,
+	_ string,
+) ([]domain.JoinRequestDecision, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	decisions := make([]domain.JoinRequestDecision, 0, len(userIDs))
+	for _, userID := range userIDs {
+		s.decided = append(s.decided, userID)
+		if err := s.itemErrs[userID]; err != nil {
+			decisions = append(decisions, domain.JoinRequestDecision{UserID: userID, Err: err})
+			continue
+		}
+		decisions = append(decisions, domain.JoinRequestDecision{UserID: userID, Status: status})
+	}
+	return decisions, nil
+}
+
+func (s *memoryStorage) AddNewMember(_ context.Context, _, _, _ int64) (domain.JoinRequestStatus, error) {
+	s.calls++
+	return s.status, s.err
+}
+
+// memoryPermissions grants the permissions of every user in the map, other users are not club members.
+type memoryPermissions map[int64]uint64
+
+func (p memoryPermissions) HasPermission(_ context.Context, _, userID int64, permission uint64) (bool, error) {
+	permissions, ok := p[userID]
+	if !ok {
+		return false, accessControl.ErrUserNotClubMember
+	}
+	return domain.HasPermission(permissions, permission), nil
+}
+
+func (p memoryPermissions) CanActOnMember(ctx context.Context, clubID, userID, _ int64, permission uint64) (bool, error) {
+	return p.HasPermission(ctx, clubID, userID, permission)
+}
+
+func newTestService(storage Storage) *Service {
+	return New(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		storage,
+		memoryPermissions{reviewerID: domain.ManageMembership, applicant: 0},
+	)
+}
+
+func TestService_CreateJoinRequest(t *testing.T) {
+	errBroken := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		storageErr error
+		status     domain.JoinRequestStatus
+		wantErr    error
+	}{
+		{"Request to approval club stays pending", nil, domain.JoinRequestStatusPending, nil},
+		{"Request to open club is approved", nil, domain.JoinRequestStatusApproved, nil},
+		{"Request to full open club is waitlisted", nil, domain.JoinRequestStatusWaitlisted, nil},
+		{"Invite-only club", storage.ErrClubInviteOnly, "", ErrClubInviteOnly},
+		{"Closed club", storage.ErrClubClosed, "", ErrClubClosed},
+		{"Inactive club", storage.ErrClubNotActive, "", ErrClubNotActive},
+		{"Missing club", storage.ErrClubNotExists, "", ErrClubNotExists},
+		{"Banned user", storage.ErrUserBanned, "", ErrUserBanned},
+		{"Member", storage.ErrUserIsClubMember, "", ErrUserIsClubMember},
+		{"Open request exists", storage.ErrJoinRequestExists, "", ErrJoinRequestExists},
+		{"Storage failure", errBroken, "", errBroken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{err: tt.storageErr, status: tt.status}
+
+			err := newTestService(memory).CreateJoinRequest(context.Background(), applicant, testClubID, "hello")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateJoinRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if memory.calls != 1 {
+				t.Errorf("InsertJoinRequest called %d times, want 1", memory.calls)
+			}
+		})
+	}
+}
+
+func TestService_HandleJoinRequests(t *testing.T) {
+	errBroken := errors.New("connection reset")
+	tooMany := make([]int64, maxBulkJoinRequests+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 10)
+	}
+
+	tests := []struct {
+		name        string
+		actorID     int64
+		userIDs     []int64
+		decision    domain.JoinRequestStatus
+		itemErrs    map[int64]error
+		storageErr  error
+		wantDecided []int64
+		wantItemErr map[int64]error
+		wantErr     error
+	}{
+		{
+			name: "Approve all", actorID: reviewerID, userIDs: []int64{4, 5, 6}, decision: domain.JoinRequestStatusApproved,
+			wantDecided: []int64{4, 5, 6},
+		},
+		{
+			name: "Reject all", actorID: reviewerID, userIDs: []int64{4, 5}, decision: domain.JoinRequestStatusRejected,
+			wantDecided: []int64{4, 5},
+		},
+		{
+			name: "Duplicates are decided once in order", actorID: reviewerID, userIDs: []int64{5, 4, 5, 6, 4},
+			decision: domain.JoinRequestStatusApproved, wantDecided: []int64{5, 4, 6},
+		},
+		{
+			name: "Failed item does not affect the rest", actorID: reviewerID, userIDs: []int64{4, 5, 6},
+			decision:    domain.JoinRequestStatusApproved,
+			itemErrs:    map[int64]error{5: storage.ErrJoinRequestNotExists, 6: storage.ErrClubNotActive},
+			wantDecided: []int64{4, 5, 6},
+			wantItemErr: map[int64]error{5: ErrJoinRequestNotExists, 6: ErrClubNotActive},
+		},
+		{
+			name: "Nothing to decide", actorID: reviewerID, userIDs: nil, decision: domain.JoinRequestStatusApproved,
+		},
+		{
+			name: "Decision other than approve or reject", actorID: reviewerID, userIDs: []int64{4},
+			decision: domain.JoinRequestStatusWaitlisted, wantErr: ErrInvalidDecision,
+		},
+		{
+			name: "Too many join requests", actorID: reviewerID, userIDs: tooMany,
+			decision: domain.JoinRequestStatusApproved, wantErr: ErrTooManyJoinRequests,
+		},
+		{
+			name: "Without ManageMembership", actorID: applicant, userIDs: []int64{4},
+			decision: domain.JoinRequestStatusApproved, wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "Actor is not member", actorID: 99, userIDs: []int64{4},
+			decision: domain.JoinRequestStatusApproved, wantErr: ErrUserNotClubMember,
+		},
+		{
+			name: "Storage failure", actorID: reviewerID, userIDs: []int64{4},
+			decision: domain.JoinRequestStatusApproved, storageErr: errBroken, wantErr: errBroken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{err: tt.storageErr, itemErrs: tt.itemErrs}
+
+			decisions, err := newTestService(memory).HandleJoinRequests(
+				context.Background(), testClubID, tt.actorID, tt.userIDs, tt.decision, "",
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandleJoinRequests() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(memory.decided, tt.wantDecided) {
+				t.Errorf("decided join requests of %v, want %v", memory.decided, tt.wantDecided)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(decisions) != len(tt.wantDecided) {
+				t.Fatalf("got %d decisions, want %d", len(decisions), len(tt.wantDecided))
+			}
+			for _, d := range decisions {
+				if want := tt.wantItemErr[d.UserID]; !errors.Is(d.Err, want) {
+					t.Errorf("decision on user %d error = %v, want %v", d.UserID, d.Err, want)
+				}
+				if d.Err == nil && d.Status != tt.decision {
+					t.Errorf("decision on user %d status = %v, want %v", d.UserID, d.Status, tt.decision)
+				}
+			}
+		})
+	}
+}
+
+func TestService_ApproveMembership(t *testing.T) {
+	errBroken := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		status     domain.JoinRequestStatus
+		storageErr error
+		wantErr    error
+	}{
+		{"Approved", domain.JoinRequestStatusApproved, nil, nil},
+		{"Full club waitlists the request", domain.JoinRequestStatusWaitlisted, nil, nil},
+		{"Request is not pending", "", storage.ErrJoinRequestNotExists, ErrJoinRequestNotExists},
+		{"Inactive club", "", storage.ErrClubNotActive, ErrClubNotActive},
+		{"Storage failure", "", errBroken, errBroken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{status: tt.status, err: tt.storageErr}
+
+			err := newTestService(memory).ApproveMembership(context.Background(), testClubID, reviewerID, applicant)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApproveMembership() error = %v, want %v", err, tt.wantErr)
+			}
+			if memory.calls != 1 {
+				t.Errorf("AddNewMember called %d times, want 1", memory.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
+	"log/slog"
+	"time"
+)
+
+// Relay publishes events written to the outbox and marks them sent.
+// A message is marked sent only after the broker confirmed it, so delivery is at-least-once
+// and consumers must tolerate duplicates.
+type Relay struct {
+	log       *slog.Logger
+	storage   Storage
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+type Storage interface {
+	FetchUnsentMessages(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+	MarkMessageSent(ctx context.Context, id int64) error
+}
+
+// Publisher returns nil only once the broker has accepted the message and routed it to a queue.
+type Publisher interface {
+	Publish(ctx context.Context, routingKey string, msg any) error
+}
+
+func New(log *slog.Logger, storage Storage, publisher Publisher, interval time.Duration, batchSize int) *Relay {
+	return &Relay{
+		log:       log,
+		storage:   storage,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run relays outbox messages every interval until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	const op = "services.outbox.Run"
+	log := r.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("outbox relay stopped")
+			return
+		case <-ticker.C:
+			// Drain the backlog before waiting for the next tick.
+			for {
+				n, err := r.RelayBatch(ctx)
+				if err != nil {
+					log.Error("failed to relay outbox messages", logger.Err(err))
+					break
+				}
+				if n < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RelayBatch publishes up to batchSize unsent messages in order and returns how many were sent.
+// It stops at the first failed message, so that the order of events is preserved.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	const op = "services.outbox.RelayBatch"
+
+	messages, err := r.storage.FetchUnsentMessages(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, msg := range messages {
+		err = r.publisher.Publish(ctx, msg.RoutingKey, msg.Payload)
+		if err != nil {
+			return i, fmt.Errorf("%s: failed to publish message %d: %w", op, msg.ID, err)
+		}
+
+		err = r.storage.MarkMessageSent(ctx, msg.ID)
+		if err != nil {
+			return i, fmt.Errorf("%s: failed to mark message %d sent: %w", op, msg.ID, err)
+		}
+	}
+
+	return len(messages), nil
+}
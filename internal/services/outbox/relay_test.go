@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type memoryStorage struct {
+	messages []*domain.OutboxMessage
+	sent     map[int64]bool
+}
+
+func newMemoryStorage(n int) *memoryStorage {
+	s := &memoryStorage{sent: make(map[int64]bool)}
+	for i := 1; i <= n; i++ {
+		s.messages = append(s.messages, &domain.OutboxMessage{
+			ID:         int64(i),
+			RoutingKey: domain.EventMemberJoined,
+			Payload:    json.RawMessage(`{"club_id":1}`),
+		})
+	}
+	return s
+}
+
+func (s *memoryStorage) FetchUnsentMessages(_ context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	var unsent []*domain.OutboxMessage
+	for _, msg := range s.messages {
+		if len(unsent) == limit {
+			break
+		}
+		if !s.sent[msg.ID] {
+			unsent = append(unsent, msg)
+		}
+	}
+	return unsent, nil
+}
+
+func (s *memoryStorage) MarkMessageSent(_ context.Context, id int64) error {
+	s.sent[id] = true
+	return nil
+}
+
+// memoryPublisher fails the failOn-th publish attempt once.
+type memoryPublisher struct {
+	published int
+	attempts  int
+	failOn    int
+}
+
+func (p *memoryPublisher) Publish(_ context.Context, _ string, _ any) error {
+	p.attempts++
+	if p.attempts == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published++
+	return nil
+}
+
+func TestRelay_RelayBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		messages  int
+		batchSize int
+		failOn    int
+		// sent is the number of messages sent after each RelayBatch call.
+		sent    []int
+		wantErr []bool
+	}{
+		{"Empty outbox", 0, 10, 0, []int{0}, []bool{false}},
+		{"Single batch", 3, 10, 0, []int{3, 0}, []bool{false, false}},
+		{"Multiple batches", 5, 2, 0, []int{2, 2, 1}, []bool{false, false, false}},
+		{"Publish failure is retried", 3, 10, 2, []int{1, 2}, []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newMemoryStorage(tt.messages)
+			publisher := &memoryPublisher{failOn: tt.failOn}
+			relay := New(slog.New(slog.NewTextHandler(io.Discard, nil)), storage, publisher, time.Second, tt.batchSize)
+
+			for i, want := range tt.sent {
+				got, err := relay.RelayBatch(context.Background())
+				if (err != nil) != tt.wantErr[i] {
+					t.Fatalf("RelayBatch() call %d error = %v, wantErr %v", i, err, tt.wantErr[i])
+				}
+				if got != want {
+					t.Errorf("RelayBatch() call %d = %d, want %d", i, got, want)
+				}
+			}
+
+			if publisher.published != tt.messages {
+				t.Errorf("published %d messages, want %d", publisher.published, tt.messages)
+			}
+			if len(storage.sent) != tt.messages {
+				t.Errorf("marked %d messages sent, want %d", len(storage.sent), tt.messages)
+			}
+		})
+	}
+}
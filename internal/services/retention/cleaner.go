@@ -0,0 +1,80 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
+	"log/slog"
+	"time"
+)
+
+// Cleaner periodically deletes processed message IDs older than the retention period,
+// so that the deduplication table does not grow forever.
+type Cleaner struct {
+	log       *slog.Logger
+	storage   Storage
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+type Storage interface {
+	DeleteProcessedMessages(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+func New(log *slog.Logger, storage Storage, retention, interval time.Duration, batchSize int) *Cleaner {
+	return &Cleaner{
+		log:       log,
+		storage:   storage,
+		retention: retention,
+		interval:  interval,
+		batchSize: batchSize,
+		now:       time.Now,
+	}
+}
+
+// Run cleans up every interval until ctx is cancelled.
+func (c *Cleaner) Run(ctx context.Context) {
+	const op = "services.retention.Run"
+	log := c.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("retention cleaner stopped")
+			return
+		case <-ticker.C:
+			deleted, err := c.Clean(ctx)
+			if err != nil {
+				log.Error("failed to delete processed messages", logger.Err(err))
+				continue
+			}
+			if deleted > 0 {
+				log.Info("deleted processed messages", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+// Clean deletes the expired processed message IDs in batches and returns how many were deleted.
+func (c *Cleaner) Clean(ctx context.Context) (int64, error) {
+	const op = "services.retention.Clean"
+
+	before := c.now().Add(-c.retention)
+
+	var total int64
+	for {
+		deleted, err := c.storage.DeleteProcessedMessages(ctx, before, c.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", op, err)
+		}
+		total += deleted
+		if deleted < int64(c.batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// memoryStorage keeps the processing times of message IDs.
+type memoryStorage struct {
+	processed map[string]time.Time
+	err       error
+	calls     int
+}
+
+func (s *memoryStorage) DeleteProcessedMessages(_ context.Context, before time.Time, limit int) (int64, error) {
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	var deleted int64
+	for id, processedAt := range s.processed {
+		if deleted == int64(limit) {
+			break
+		}
+		if processedAt.Before(before) {
+			delete(s.processed, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestCleaner_Clean(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	errBroken := errors.New("connection reset")
+
+	tests := []struct {
+		name        string
+		expired     int
+		fresh       int
+		storageErr  error
+		wantDeleted int64
+		wantCalls   int
+		wantErr     error
+	}{
+		{"Nothing expired", 0, 3, nil, 0, 1, nil},
+		{"Less than a batch", 2, 3, nil, 2, 1, nil},
+		{"Several batches", 7, 3, nil, 7, 3, nil},
+		{"Exactly one batch", 3, 0, nil, 3, 2, nil},
+		{"Storage failure", 2, 0, errBroken, 0, 1, errBroken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{processed: make(map[string]time.Time), err: tt.storageErr}
+			for i := 0; i < tt.expired; i++ {
+				memory.processed[fmt.Sprintf("expired-%d", i)] = now.Add(-8 * 24 * time.Hour)
+			}
+			for i := 0; i < tt.fresh; i++ {
+				memory.processed[fmt.Sprintf("fresh-%d", i)] = now.Add(-time.Hour)
+			}
+
+			cleaner := New(slog.New(slog.NewTextHandler(io.Discard, nil)), memory, 7*24*time.Hour, time.Hour, 3)
+			cleaner.now = func() time.Time { return now }
+
+			deleted, err := cleaner.Clean(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Clean() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted %d messages, want %d", deleted, tt.wantDeleted)
+			}
+			if memory.calls != tt.wantCalls {
+				t.Errorf("storage called %d times, want %d", memory.calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil && len(memory.processed) != tt.fresh {
+				t.Errorf("kept %d messages, want %d fresh ones", len(memory.processed), tt.fresh)
+			}
+		})
+	}
+}
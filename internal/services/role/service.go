@@ -0,0 +1,379 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
+	"log/slog"
+)
+
+var (
+	ErrRoleNotExists     = errors.New("role does not exists")
+	ErrDefaultRole       = errors.New("default member role can not be moved, revoked or deleted")
+	ErrInvalidPosition   = errors.New("role position must be greater than zero")
+	ErrUserNotClubMember = errors.New("user is not club member")
+	ErrPermissionDenied  = errors.New("user does not have permission")
+	ErrTargetNotMember   = errors.New("target user is not club member")
+	ErrRoleNotAssigned   = errors.New("role is not assigned to user")
+)
+
+type Service struct {
+	log        *slog.Logger
+	storage    Storage
+	permission PermissionChecker
+}
+
+type Storage interface {
+	GetRole(ctx context.Context, clubID int64, roleID int) (*domain.Role, error)
+	CreateRole(ctx context.Context, dto dtos.CreateRoleDTO, audit domain.AuditEntry) (*domain.Role, error)
+	UpdateRole(ctx context.Context, dto dtos.UpdateRoleDTO, audit domain.AuditEntry) (*domain.Role, error)
+	MoveRole(ctx context.Context, clubID, actorID int64, roleID int, position int, audit domain.AuditEntry) error
+	DeleteRole(ctx context.Context, clubID, actorID int64, roleID int, audit domain.AuditEntry) error
+	AssignRole(ctx context.Context, clubID, actorID, userID int64, roleID int, audit domain.AuditEntry) error
+	RevokeRole(ctx context.Context, clubID, actorID, userID int64, roleID int, audit domain.AuditEntry) error
+	GetClubMember(ctx context.Context, clubID, userID int64) (*domain.User, error)
+}
+
+type PermissionChecker interface {
+	CanManageRole(ctx context.Context, clubID, userID int64, position int, permissions uint64) (bool, error)
+	CanActOnMember(ctx context.Context, clubID, userID, targetID int64, permission uint64) (bool, error)
+}
+
+func New(log *slog.Logger, storage Storage, permission PermissionChecker) *Service {
+	return &Service{
+		log:        log,
+		storage:    storage,
+		permission: permission,
+	}
+}
+
+func (s Service) CreateRole(ctx context.Context, dto dtos.CreateRoleDTO) (*domain.Role, error) {
+	const op = "services.role.CreateRole"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", dto.ClubID))
+
+	if dto.Position < 1 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPosition)
+	}
+
+	err := s.authorize(ctx, dto.ClubID, dto.ActorID, dto.Position, dto.Permissions)
+	if err != nil {
+		log.Warn("creating role is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	role, err := s.storage.CreateRole(ctx, dto, domain.AuditEntry{
+		ClubID:  dto.ClubID,
+		ActorID: dto.ActorID,
+		Action:  domain.AuditRoleCreated,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrRolePositionTooHigh) {
+			log.Warn("creating role is not allowed", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to create role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role, nil
+}
+
+// UpdateRole renames, recolors or changes permissions of the role.
+func (s Service) UpdateRole(ctx context.Context, dto dtos.UpdateRoleDTO) (*domain.Role, error) {
+	const op = "services.role.UpdateRole"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", dto.ClubID), slog.Int("role_id", dto.RoleID))
+
+	role, err := s.getRole(ctx, dto.ClubID, dto.RoleID)
+	if err != nil {
+		log.Error("failed to get role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if dto.IsEmpty() {
+		return role, nil
+	}
+
+	var permissions uint64
+	if dto.Permissions != nil {
+		permissions = *dto.Permissions
+	}
+	err = s.authorize(ctx, dto.ClubID, dto.ActorID, role.Position, permissions)
+	if err != nil {
+		log.Warn("updating role is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.storage.UpdateRole(ctx, dto, domain.AuditEntry{
+		ClubID:  dto.ClubID,
+		ActorID: dto.ActorID,
+		Action:  domain.AuditRoleUpdated,
+		Before:  role.AuditPayload(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRoleNotExists):
+			log.Error("role does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrRoleNotExists)
+		case errors.Is(err, storage.ErrRolePositionTooHigh):
+			log.Warn("updating role is not allowed", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to update role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// MoveRole changes position of the role, both the old and the new position must be below the actor's highest role.
+func (s Service) MoveRole(ctx context.Context, clubID, actorID int64, roleID int, position int) error {
+	const op = "services.role.MoveRole"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int("role_id", roleID))
+
+	if position < 1 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidPosition)
+	}
+
+	role, err := s.getRole(ctx, clubID, roleID)
+	if err != nil {
+		log.Error("failed to get role", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if role.IsDefault {
+		return fmt.Errorf("%s: %w", op, ErrDefaultRole)
+	}
+
+	err = s.authorize(ctx, clubID, actorID, max(role.Position, position), 0)
+	if err != nil {
+		log.Warn("moving role is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	moved := *role
+	moved.Position = position
+	err = s.storage.MoveRole(ctx, clubID, actorID, roleID, position, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditRoleMoved,
+		Before:  role.AuditPayload(),
+		After:   moved.AuditPayload(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRoleNotExists):
+			log.Error("role does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrRoleNotExists)
+		case errors.Is(err, storage.ErrRolePositionTooHigh):
+			log.Warn("moving role is not allowed", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to move role", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s Service) DeleteRole(ctx context.Context, clubID, actorID int64, roleID int) error {
+	const op = "services.role.DeleteRole"
+	log := s.log.With(slog.String("op", op), slog.Int64("club_id", clubID), slog.Int("role_id", roleID))
+
+	role, err := s.getRole(ctx, clubID, roleID)
+	if err != nil {
+		log.Error("failed to get role", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if role.IsDefault {
+		return fmt.Errorf("%s: %w", op, ErrDefaultRole)
+	}
+
+	err = s.authorize(ctx, clubID, actorID, role.Position, 0)
+	if err != nil {
+		log.Warn("deleting role is not allowed", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.DeleteRole(ctx, clubID, actorID, roleID, domain.AuditEntry{
+		ClubID:  clubID,
+		ActorID: actorID,
+		Action:  domain.AuditRoleDeleted,
+		Before:  role.AuditPayload(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRoleNotExists):
+			log.Error("role does not exists", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrRoleNotExists)
+		case errors.Is(err, storage.ErrRolePositionTooHigh):
+			log.Warn("deleting role is not allowed", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to delete role", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AssignRole gives the role to the target member and returns the member with updated roles.
+func (s Service) AssignRole(ctx context.Context, clubID, actorID, targetID int64, roleID int) (*domain.User, error) {
+	const op = "services.role.AssignRole"
+	log := s.log.With(
+		slog.String("op", op), slog.Int64("club_id", clubID),
+		slog.Int64("target_id", targetID), slog.Int("role_id", roleID),
+	)
+
+	role, err := s.getRole(ctx, clubID, roleID)
+	if err != nil {
+		log.Error("failed to get role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.authorizeOnMember(ctx, clubID, actorID, targetID, role.Position)
+	if err != nil {
+		log.Warn("assigning role is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.AssignRole(ctx, clubID, actorID, targetID, roleID, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  actorID,
+		TargetID: targetID,
+		Action:   domain.AuditRoleAssigned,
+		After:    role.AuditPayload(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRoleNotExists):
+			log.Error("role does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrRoleNotExists)
+		case errors.Is(err, storage.ErrRolePositionTooHigh):
+			log.Warn("assigning role is not allowed", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to assign role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.getMember(ctx, log, op, clubID, targetID)
+}
+
+// RevokeRole takes the role away from the target member and returns the member with updated roles.
+func (s Service) RevokeRole(ctx context.Context, clubID, actorID, targetID int64, roleID int) (*domain.User, error) {
+	const op = "services.role.RevokeRole"
+	log := s.log.With(
+		slog.String("op", op), slog.Int64("club_id", clubID),
+		slog.Int64("target_id", targetID), slog.Int("role_id", roleID),
+	)
+
+	role, err := s.getRole(ctx, clubID, roleID)
+	if err != nil {
+		log.Error("failed to get role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if role.IsDefault {
+		return nil, fmt.Errorf("%s: %w", op, ErrDefaultRole)
+	}
+
+	err = s.authorizeOnMember(ctx, clubID, actorID, targetID, role.Position)
+	if err != nil {
+		log.Warn("revoking role is not allowed", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.RevokeRole(ctx, clubID, actorID, targetID, roleID, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  actorID,
+		TargetID: targetID,
+		Action:   domain.AuditRoleRevoked,
+		Before:   role.AuditPayload(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRoleNotAssigned):
+			log.Error("role is not assigned to user", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrRoleNotAssigned)
+		case errors.Is(err, storage.ErrRoleNotExists):
+			log.Error("role does not exists", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrRoleNotExists)
+		case errors.Is(err, storage.ErrRolePositionTooHigh):
+			log.Warn("revoking role is not allowed", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+		log.Error("failed to revoke role", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.getMember(ctx, log, op, clubID, targetID)
+}
+
+func (s Service) getMember(ctx context.Context, log *slog.Logger, op string, clubID, userID int64) (*domain.User, error) {
+	member, err := s.storage.GetClubMember(ctx, clubID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotClubMember) {
+			log.Error("target is not club member", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrTargetNotMember)
+		}
+		log.Error("failed to get club member", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+// authorizeOnMember checks that the actor outranks the target member and the role at rolePosition.
+func (s Service) authorizeOnMember(ctx context.Context, clubID, actorID, targetID int64, rolePosition int) error {
+	isAuthorized, err := s.permission.CanActOnMember(ctx, clubID, actorID, targetID, domain.ManageRoles)
+	if err != nil {
+		switch {
+		case errors.Is(err, accessControl.ErrUserNotClubMember):
+			return ErrUserNotClubMember
+		case errors.Is(err, accessControl.ErrTargetNotClubMember):
+			return ErrTargetNotMember
+		case errors.Is(err, accessControl.ErrInsufficientRolePosition):
+			return ErrPermissionDenied
+		default:
+			return err
+		}
+	}
+	if !isAuthorized {
+		return ErrPermissionDenied
+	}
+
+	return s.authorize(ctx, clubID, actorID, rolePosition, 0)
+}
+
+func (s Service) getRole(ctx context.Context, clubID int64, roleID int) (*domain.Role, error) {
+	role, err := s.storage.GetRole(ctx, clubID, roleID)
+	if err != nil {
+		if errors.Is(err, storage.ErrRoleNotExists) {
+			return nil, ErrRoleNotExists
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (s Service) authorize(ctx context.Context, clubID, actorID int64, position int, permissions uint64) error {
+	isAuthorized, err := s.permission.CanManageRole(ctx, clubID, actorID, position, permissions)
+	if err != nil {
+		switch {
+		case errors.Is(err, accessControl.ErrUserNotClubMember):
+			return ErrUserNotClubMember
+		case errors.Is(err, accessControl.ErrInsufficientRolePosition):
+			return ErrPermissionDenied
+		default:
+			return err
+		}
+	}
+	if !isAuthorized {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/services/accessControl"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+const (
+	testClubID    = 1
+	ownerID       = 1
+	adminID       = 2
+	moderatorID   = 3
+	memberID      = 4
+	newcomerID    = 5
+	nonMemberID   = 9
+	memberRoleID  = 1
+	helperRoleID  = 2
+	modRoleID     = 3
+	adminRoleID   = 4
+	missingRoleID = 99
+)
+
+// memoryClub is a single club with its roles and members. It serves both the role service and
+// the access control service, so that the role hierarchy is enforced by the real permission checks.
+type memoryClub struct {
+	Storage
+	roles   map[int]*domain.Role
+	members map[int64][]int
+	// positionTooHigh makes the storage refuse role changes as if the actor lost rank concurrently.
+	positionTooHigh bool
+	changes         int
+}
+
+// newMemoryClub returns a club where the admin outranks the moderator, who outranks the members.
+func newMemoryClub() *memoryClub {
+	return &memoryClub{
+		roles: map[int]*domain.Role{
+			memberRoleID: {ID: memberRoleID, Name: "member", Position: 0, IsDefault: true},
+			helperRoleID: {ID: helperRoleID, Name: "helper", Position: 1},
+			modRoleID: {
+				ID: modRoleID, Name: "moderator", Position: 2,
+				Permissions: domain.Permissions{PermissionsHex: domain.ManageRoles},
+			},
+			adminRoleID: {
+				ID: adminRoleID, Name: "admin", Position: 3,
+				Permissions: domain.Permissions{PermissionsHex: domain.ManageRoles | domain.KickMember},
+			},
+		},
+		members: map[int64][]int{
+			ownerID:     {memberRoleID},
+			adminID:     {memberRoleID, adminRoleID},
+			moderatorID: {memberRoleID, modRoleID},
+			memberID:    {memberRoleID},
+			newcomerID:  {memberRoleID},
+		},
+	}
+}
+
+func (c *memoryClub) GetUserRoles(_ context.Context, _, userID int64) ([]domain.Role, bool, error) {
+	roleIDs, ok := c.members[userID]
+	if !ok {
+		return nil, false, storage.ErrUserNotClubMember
+	}
+	roles := make([]domain.Role, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		roles = append(roles, *c.roles[id])
+	}
+	return roles, userID == ownerID, nil
+}
+
+func (c *memoryClub) GetRole(_ context.Context, _ int64, roleID int) (*domain.Role, error) {
+	role, ok := c.roles[roleID]
+	if !ok {
+		return nil, storage.ErrRoleNotExists
+	}
+	r := *role
+	return &r, nil
+}
+
+func (c *memoryClub) CreateRole(_ context.Context, dto dtos.CreateRoleDTO, _ domain.AuditEntry) (*domain.Role, error) {
+	if c.positionTooHigh {
+		return nil, storage.ErrRolePositionTooHigh
+	}
+	c.changes++
+	return &domain.Role{ID: len(c.roles) + 1, Name: dto.Name, Position: dto.Position}, nil
+}
+
+func (c *memoryClub) MoveRole(_ context.Context, _, _ int64, _ int, _ int, _ domain.AuditEntry) error {
+	if c.positionTooHigh {
+		return storage.ErrRolePositionTooHigh
+	}
+	c.changes++
+	return nil
+}
+
+func (c *memoryClub) DeleteRole(_ context.Context, _, _ int64, _ int, _ domain.AuditEntry) error {
+	if c.positionTooHigh {
+		return storage.ErrRolePositionTooHigh
+	}
+	c.changes++
+	return nil
+}
+
+func (c *memoryClub) UpdateRole(_ context.Context, dto dtos.UpdateRoleDTO, _ domain.AuditEntry) (*domain.Role, error) {
+	if c.positionTooHigh {
+		return nil, storage.ErrRolePositionTooHigh
+	}
+	c.changes++
+	role := *c.roles[dto.RoleID]
+	if dto.Name != nil {
+		role.Name = *dto.Name
+	}
+	return &role, nil
+}
+
+func (c *memoryClub) AssignRole(_ context.Context, _, _, userID int64, roleID int, _ domain.AuditEntry) error {
+	if c.positionTooHigh {
+		return storage.ErrRolePositionTooHigh
+	}
+	if !slices.Contains(c.members[userID], roleID) {
+		c.members[userID] = append(c.members[userID], roleID)
+	}
+	c.changes++
+	return nil
+}
+
+func (c *memoryClub) RevokeRole(_ context.Context, _, _, userID int64, roleID int, _ domain.AuditEntry) error {
+	if c.positionTooHigh {
+		return storage.ErrRolePositionTooHigh
+	}
+	i := slices.Index(c.members[userID], roleID)
+	if i < 0 {
+		return storage.ErrRoleNotAssigned
+	}
+	c.members[userID] = slices.Delete(c.members[userID], i, i+1)
+	c.changes++
+	return nil
+}
+
+func (c *memoryClub) GetClubMember(_ context.Context, _, userID int64) (*domain.User, error) {
+	roles, _, err := c.GetUserRoles(context.Background(), testClubID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.User{ID: userID, Roles: roles}, nil
+}
+
+func newTestService(club *memoryClub) *Service {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return New(log, club, accessControl.New(log, club))
+}
+
+func TestService_CreateRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actorID         int64
+		position        int
+		permissions     uint64
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner creates role above everyone", ownerID, 5, domain.ALL, false, nil},
+		{"Admin creates role below own", adminID, 2, domain.ManageRoles, false, nil},
+		{"Admin creates role at own position", adminID, 3, 0, false, ErrPermissionDenied},
+		{"Admin grants permission it lacks", adminID, 1, domain.BanMember, false, ErrPermissionDenied},
+		{"Member without ManageRoles", memberID, 1, 0, false, ErrPermissionDenied},
+		{"Non-member", nonMemberID, 1, 0, false, ErrUserNotClubMember},
+		{"Position of default role", ownerID, 0, 0, false, ErrInvalidPosition},
+		{"Actor outranked concurrently", adminID, 2, 0, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			_, err := newTestService(club).CreateRole(context.Background(), dtos.CreateRoleDTO{
+				ClubID:      testClubID,
+				ActorID:     tt.actorID,
+				Name:        "new",
+				Permissions: tt.permissions,
+				Position:    tt.position,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantChanges := boolToInt(tt.wantErr == nil); club.changes != wantChanges {
+				t.Errorf("storage changes = %d, want %d", club.changes, wantChanges)
+			}
+		})
+	}
+}
+
+func TestService_UpdateRole(t *testing.T) {
+	name := "renamed"
+
+	tests := []struct {
+		name            string
+		actorID         int64
+		roleID          int
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner renames admin role", ownerID, adminRoleID, false, nil},
+		{"Admin renames role below own", adminID, modRoleID, false, nil},
+		{"Admin renames own role", adminID, adminRoleID, false, ErrPermissionDenied},
+		{"Member without ManageRoles", memberID, helperRoleID, false, ErrPermissionDenied},
+		{"Missing role", ownerID, missingRoleID, false, ErrRoleNotExists},
+		{"Actor outranked concurrently", adminID, helperRoleID, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			role, err := newTestService(club).UpdateRole(context.Background(), dtos.UpdateRoleDTO{
+				ClubID:  testClubID,
+				RoleID:  tt.roleID,
+				ActorID: tt.actorID,
+				Name:    &name,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantChanges := boolToInt(tt.wantErr == nil); club.changes != wantChanges {
+				t.Errorf("storage changes = %d, want %d", club.changes, wantChanges)
+			}
+			if tt.wantErr == nil && role.Name != name {
+				t.Errorf("role name = %q, want %q", role.Name, name)
+			}
+		})
+	}
+}
+
+func TestService_MoveRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actorID         int64
+		roleID          int
+		position        int
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner moves admin role up", ownerID, adminRoleID, 4, false, nil},
+		{"Admin moves role below own", adminID, helperRoleID, 2, false, nil},
+		{"Admin moves role to own position", adminID, modRoleID, 3, false, ErrPermissionDenied},
+		{"Admin moves own role down", adminID, adminRoleID, 1, false, ErrPermissionDenied},
+		{"Moderator moves role it does not outrank", moderatorID, modRoleID, 1, false, ErrPermissionDenied},
+		{"Default role", ownerID, memberRoleID, 1, false, ErrDefaultRole},
+		{"Missing role", ownerID, missingRoleID, 1, false, ErrRoleNotExists},
+		{"Position of default role", ownerID, helperRoleID, 0, false, ErrInvalidPosition},
+		{"Actor outranked concurrently", adminID, helperRoleID, 2, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			err := newTestService(club).MoveRole(context.Background(), testClubID, tt.actorID, tt.roleID, tt.position)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantChanges := boolToInt(tt.wantErr == nil); club.changes != wantChanges {
+				t.Errorf("storage changes = %d, want %d", club.changes, wantChanges)
+			}
+		})
+	}
+}
+
+func TestService_DeleteRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actorID         int64
+		roleID          int
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner deletes admin role", ownerID, adminRoleID, false, nil},
+		{"Admin deletes role below own", adminID, modRoleID, false, nil},
+		{"Moderator deletes role above own", moderatorID, adminRoleID, false, ErrPermissionDenied},
+		{"Moderator deletes own role", moderatorID, modRoleID, false, ErrPermissionDenied},
+		{"Default role", ownerID, memberRoleID, false, ErrDefaultRole},
+		{"Missing role", ownerID, missingRoleID, false, ErrRoleNotExists},
+		{"Actor outranked concurrently", adminID, helperRoleID, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			err := newTestService(club).DeleteRole(context.Background(), testClubID, tt.actorID, tt.roleID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantChanges := boolToInt(tt.wantErr == nil); club.changes != wantChanges {
+				t.Errorf("storage changes = %d, want %d", club.changes, wantChanges)
+			}
+		})
+	}
+}
+
+func TestService_AssignRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actorID         int64
+		targetID        int64
+		roleID          int
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner assigns admin role", ownerID, memberID, adminRoleID, false, nil},
+		{"Admin assigns role below own", adminID, memberID, modRoleID, false, nil},
+		{"Admin assigns own role", adminID, memberID, adminRoleID, false, ErrPermissionDenied},
+		{"Moderator acts on admin", moderatorID, adminID, helperRoleID, false, ErrPermissionDenied},
+		{"Admin acts on owner", adminID, ownerID, helperRoleID, false, ErrPermissionDenied},
+		{"Member without ManageRoles", memberID, newcomerID, helperRoleID, false, ErrPermissionDenied},
+		{"Target is not member", adminID, nonMemberID, helperRoleID, false, ErrTargetNotMember},
+		{"Actor is not member", nonMemberID, memberID, helperRoleID, false, ErrUserNotClubMember},
+		{"Missing role", ownerID, memberID, missingRoleID, false, ErrRoleNotExists},
+		{"Actor outranked concurrently", adminID, memberID, helperRoleID, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			member, err := newTestService(club).AssignRole(context.Background(), testClubID, tt.actorID, tt.targetID, tt.roleID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssignRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if club.changes != 0 {
+					t.Errorf("storage changes = %d, want 0", club.changes)
+				}
+				return
+			}
+			if !hasRole(member.Roles, tt.roleID) {
+				t.Errorf("member roles = %v, want role %d", member.Roles, tt.roleID)
+			}
+		})
+	}
+}
+
+func TestService_RevokeRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actorID         int64
+		targetID        int64
+		roleID          int
+		positionTooHigh bool
+		wantErr         error
+	}{
+		{"Owner revokes admin role", ownerID, adminID, adminRoleID, false, nil},
+		{"Admin revokes role below own", adminID, moderatorID, modRoleID, false, nil},
+		{"Moderator revokes role of admin", moderatorID, adminID, adminRoleID, false, ErrPermissionDenied},
+		{"Default role", ownerID, memberID, memberRoleID, false, ErrDefaultRole},
+		{"Role not assigned", ownerID, memberID, helperRoleID, false, ErrRoleNotAssigned},
+		{"Missing role", ownerID, memberID, missingRoleID, false, ErrRoleNotExists},
+		{"Actor outranked concurrently", adminID, moderatorID, modRoleID, true, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			club := newMemoryClub()
+			club.positionTooHigh = tt.positionTooHigh
+
+			member, err := newTestService(club).RevokeRole(context.Background(), testClubID, tt.actorID, tt.targetID, tt.roleID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevokeRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if club.changes != 0 {
+					t.Errorf("storage changes = %d, want 0", club.changes)
+				}
+				return
+			}
+			if hasRole(member.Roles, tt.roleID) {
+				t.Errorf("member roles = %v, want no role %d", member.Roles, tt.roleID)
+			}
+		})
+	}
+}
+
+func hasRole(roles []domain.Role, roleID int) bool {
+	return slices.ContainsFunc(roles, func(r domain.Role) bool { return r.ID == roleID })
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/rabbitmq"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
 	"github.com/ARUMANDESU/uniclubs-club-service/pkg/logger"
 	"github.com/rabbitmq/amqp091-go"
@@ -15,6 +16,7 @@ import (
 var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotExist = errors.New("user does not exist")
+	ErrUserConflict = errors.New("email or barcode belongs to another user")
 )
 
 type Service struct {
@@ -23,10 +25,11 @@ type Service struct {
 }
 
 type Storage interface {
-	SaveUser(ctx context.Context, user *domain.User) error
+	SaveUser(ctx context.Context, user *domain.User, meta domain.MessageMeta) error
 	GetUserByID(ctx context.Context, userID int64) (user *domain.User, err error)
-	UpdateUser(ctx context.Context, user *domain.User) error
-	DeleteUserByID(ctx context.Context, userID int64) error
+	UpdateUser(ctx context.Context, user *domain.User, meta domain.MessageMeta) error
+	DeleteUserByID(ctx context.Context, userID int64, meta domain.MessageMeta) error
+	RequestUserResync(ctx context.Context, user *domain.User) ([]int64, error)
 }
 
 func New(log *slog.Logger, storage Storage) *Service {
@@ -45,15 +48,19 @@ func (s Service) HandleCreateUser(msg amqp091.Delivery) error {
 	err := json.Unmarshal(msg.Body, &input)
 	if err != nil {
 		log.Error("failed to unmarshal message", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, rabbitmq.Permanent(err))
 	}
 
-	err = s.usrStorage.SaveUser(context.Background(), &input)
+	err = s.usrStorage.SaveUser(context.Background(), &input, messageMeta(msg))
 	if err != nil {
 		switch {
-		case errors.Is(err, storage.ErrUserExists):
-			log.Error("user already exists", logger.Err(err))
-			return fmt.Errorf("%s: %w", op, ErrUserExists)
+		case errors.Is(err, storage.ErrMessageProcessed):
+			log.Info("message already processed", slog.String("message_id", msg.MessageId))
+			return nil
+		case errors.Is(err, storage.ErrUserConflict):
+			log.Warn("email or barcode belongs to another user", logger.Err(err))
+			s.reconcile(context.Background(), log, &input)
+			return fmt.Errorf("%s: %w", op, ErrUserConflict)
 
 		default:
 			log.Error("failed to save user", logger.Err(err))
@@ -75,12 +82,14 @@ func (s Service) HandleUpdateUser(msg amqp091.Delivery) error {
 		FirstName *string `json:"first_name"`
 		LastName  *string `json:"last_name"`
 		AvatarURL *string `json:"avatar_url"`
+		Email     *string `json:"email"`
+		Barcode   *string `json:"barcode"`
 	}
 
 	err := json.Unmarshal(msg.Body, &input)
 	if err != nil {
 		log.Error("failed to unmarshal message", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, rabbitmq.Permanent(err))
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -108,10 +117,26 @@ func (s Service) HandleUpdateUser(msg amqp091.Delivery) error {
 	if input.AvatarURL != nil {
 		user.AvatarURL = *input.AvatarURL
 	}
+	if input.Email != nil {
+		user.Email = *input.Email
+	}
+	if input.Barcode != nil {
+		user.Barcode = *input.Barcode
+	}
 
-	err = s.usrStorage.UpdateUser(ctx, user)
+	err = s.usrStorage.UpdateUser(ctx, user, messageMeta(msg))
 	if err != nil {
 		switch {
+		case errors.Is(err, storage.ErrMessageProcessed):
+			log.Info("message already processed", slog.String("message_id", msg.MessageId))
+			return nil
+		case errors.Is(err, storage.ErrStaleEvent):
+			log.Info("ignoring stale update", slog.Int64("user_id", user.ID))
+			return nil
+		case errors.Is(err, storage.ErrUserConflict):
+			log.Warn("email or barcode belongs to another user", logger.Err(err))
+			s.reconcile(ctx, log, user)
+			return fmt.Errorf("%s: %w", op, ErrUserConflict)
 		case errors.Is(err, storage.ErrUserNotExists):
 			log.Error("user not found", logger.Err(err))
 			return fmt.Errorf("%s: %w", op, ErrUserNotExist)
@@ -134,15 +159,21 @@ func (s Service) HandleDeleteUser(msg amqp091.Delivery) error {
 	err := json.Unmarshal(msg.Body, &userID)
 	if err != nil {
 		log.Error("failed to unmarshal message", logger.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, rabbitmq.Permanent(err))
 	}
 
-	err = s.usrStorage.DeleteUserByID(context.Background(), userID)
+	err = s.usrStorage.DeleteUserByID(context.Background(), userID, messageMeta(msg))
 	if err != nil {
 		switch {
+		case errors.Is(err, storage.ErrMessageProcessed):
+			log.Info("message already processed", slog.String("message_id", msg.MessageId))
+			return nil
+		case errors.Is(err, storage.ErrStaleEvent):
+			log.Info("ignoring stale delete", slog.Int64("user_id", userID))
+			return nil
 		case errors.Is(err, storage.ErrUserNotExists):
-			log.Error("user not found", logger.Err(err))
-			return fmt.Errorf("%s: %w", op, ErrUserNotExist)
+			log.Info("user already deleted", slog.Int64("user_id", userID))
+			return nil
 		default:
 			log.Error("failed to delete user", logger.Err(err))
 			return fmt.Errorf("%s: %w", op, err)
@@ -151,3 +182,48 @@ func (s Service) HandleDeleteUser(msg amqp091.Delivery) error {
 
 	return nil
 }
+
+// reconcile asks the user service to resend the users holding the email or barcode of user.
+// The conflicting event is retried and succeeds once their stale values are replaced.
+func (s Service) reconcile(ctx context.Context, log *slog.Logger, user *domain.User) {
+	userIDs, err := s.usrStorage.RequestUserResync(ctx, user)
+	if err != nil {
+		log.Error("failed to request user resync", logger.Err(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		log.Info("requested user resync", slog.Int64("user_id", userID), slog.Int64("conflicting_user_id", user.ID))
+	}
+}
+
+// messageMeta reads the event id from the message id property and the version from the "version" header.
+// The version is left zero, which disables the ordering check, if the header is missing or not an integer.
+func messageMeta(msg amqp091.Delivery) domain.MessageMeta {
+	meta := domain.MessageMeta{ID: msg.MessageId}
+
+	switch v := msg.Headers["version"].(type) {
+	case int:
+		meta.Version = int64(v)
+	case int8:
+		meta.Version = int64(v)
+	case int16:
+		meta.Version = int64(v)
+	case int32:
+		meta.Version = int64(v)
+	case int64:
+		meta.Version = v
+	case uint:
+		meta.Version = int64(v)
+	case uint8:
+		meta.Version = int64(v)
+	case uint16:
+		meta.Version = int64(v)
+	case uint32:
+		meta.Version = int64(v)
+	case uint64:
+		meta.Version = int64(v)
+	}
+
+	return meta
+}
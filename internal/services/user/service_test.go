@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/rabbitmq"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"github.com/rabbitmq/amqp091-go"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestMessageMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp091.Table
+		want    int64
+	}{
+		{name: "int8", headers: amqp091.Table{"version": int8(3)}, want: 3},
+		{name: "int16", headers: amqp091.Table{"version": int16(300)}, want: 300},
+		{name: "int32", headers: amqp091.Table{"version": int32(70000)}, want: 70000},
+		{name: "int64", headers: amqp091.Table{"version": int64(1 << 40)}, want: 1 << 40},
+		{name: "uint8", headers: amqp091.Table{"version": uint8(7)}, want: 7},
+		{name: "uint16", headers: amqp091.Table{"version": uint16(7)}, want: 7},
+		{name: "uint32", headers: amqp091.Table{"version": uint32(7)}, want: 7},
+		{name: "missing header", headers: nil, want: 0},
+		{name: "not an integer", headers: amqp091.Table{"version": "5"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := amqp091.Delivery{MessageId: "id", Headers: tt.headers, Timestamp: time.Now()}
+
+			meta := messageMeta(msg)
+			if meta.ID != "id" {
+				t.Errorf("ID = %q, want %q", meta.ID, "id")
+			}
+			if meta.Version != tt.want {
+				t.Errorf("Version = %d, want %d", meta.Version, tt.want)
+			}
+		})
+	}
+}
+
+// memoryStorage fails DeleteUserByID with deleteErr, methods not used by the tests are left to the embedded nil Storage.
+type memoryStorage struct {
+	Storage
+	deleteErr error
+	deleted   []int64
+}
+
+func (s *memoryStorage) DeleteUserByID(_ context.Context, userID int64, _ domain.MessageMeta) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = append(s.deleted, userID)
+	return nil
+}
+
+func TestService_HandleDeleteUser(t *testing.T) {
+	errBroken := errors.New("connection reset")
+
+	tests := []struct {
+		name          string
+		body          string
+		deleteErr     error
+		wantErr       error
+		wantPermanent bool
+	}{
+		{name: "Deleted", body: "7"},
+		{name: "Already processed", body: "7", deleteErr: storage.ErrMessageProcessed},
+		{name: "Stale event", body: "7", deleteErr: storage.ErrStaleEvent},
+		{name: "Unknown user is acknowledged", body: "7", deleteErr: storage.ErrUserNotExists},
+		{name: "Storage failure is retried", body: "7", deleteErr: errBroken, wantErr: errBroken},
+		{name: "Malformed body", body: `{"id":7}`, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &memoryStorage{deleteErr: tt.deleteErr}
+			service := New(slog.New(slog.NewTextHandler(io.Discard, nil)), memory)
+
+			err := service.HandleDeleteUser(amqp091.Delivery{MessageId: "id", Body: []byte(tt.body)})
+			if rabbitmq.IsPermanent(err) != tt.wantPermanent {
+				t.Fatalf("HandleDeleteUser() error = %v, want permanent %v", err, tt.wantPermanent)
+			}
+			if tt.wantPermanent {
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandleDeleteUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.deleteErr == nil && (len(memory.deleted) != 1 || memory.deleted[0] != 7) {
+				t.Errorf("deleted users = %v, want [7]", memory.deleted)
+			}
+		})
+	}
+}
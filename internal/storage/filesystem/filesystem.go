@@ -0,0 +1,85 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage keeps files on the local filesystem, it is meant for dev and tests.
+type Storage struct {
+	dir     string
+	baseURL string
+}
+
+func New(dir, baseURL string) (*Storage, error) {
+	const op = "storage.filesystem.New"
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// SaveFile writes data under key and returns the public URL of the file.
+func (s *Storage) SaveFile(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	const op = "storage.filesystem.SaveFile"
+
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	path, err := s.path(key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// DeleteFile removes the file stored under key, missing files are ignored.
+func (s *Storage) DeleteFile(ctx context.Context, key string) error {
+	const op = "storage.filesystem.DeleteFile"
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) path(key string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+	return path, nil
+}
@@ -0,0 +1,104 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"time"
+)
+
+// insertAuditEntry appends the entry to the audit log inside tx, so it is recorded only if tx commits.
+func insertAuditEntry(ctx context.Context, tx *sql.Tx, entry domain.AuditEntry) error {
+	before, err := auditPayload(entry.Before)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s audit before payload: %w", entry.Action, err)
+	}
+	after, err := auditPayload(entry.After)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s audit after payload: %w", entry.Action, err)
+	}
+
+	query := `
+		INSERT INTO audit_log(club_id, actor_id, target_id, action, before, after)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6);
+	`
+	_, err = tx.ExecContext(ctx, query, entry.ClubID, entry.ActorID, entry.TargetID, entry.Action, before, after)
+	if err != nil {
+		return fmt.Errorf("failed to insert %s audit entry: %w", entry.Action, err)
+	}
+
+	return nil
+}
+
+// ListAuditLog returns the page of audit entries matching filter, newest first.
+func (s *Storage) ListAuditLog(ctx context.Context, filter domain.AuditFilter, filters domain.Filters) (
+	[]*domain.AuditEntry,
+	*domain.Metadata,
+	error,
+) {
+	const op = "storage.postgresql.ListAuditLog"
+
+	query := `
+		SELECT count(*) OVER(), id, club_id, COALESCE(actor_id, 0), COALESCE(target_id, 0),
+		       action, COALESCE(before, 'null'), COALESCE(after, 'null'), created_at
+		FROM audit_log
+		WHERE club_id = $1
+			AND (actor_id = $2 OR $2 = 0)
+			AND (action = $3 OR $3 = '')
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, filter.ClubID, filter.ActorID, filter.Action, filters.Limit(), filters.Offset())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totalRecords int32
+	entries := []*domain.AuditEntry{}
+
+	for rows.Next() {
+		var (
+			entry         domain.AuditEntry
+			before, after []byte
+		)
+
+		err = rows.Scan(
+			&totalRecords, &entry.ID, &entry.ClubID, &entry.ActorID, &entry.TargetID,
+			&entry.Action, &before, &after, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entry.Before, entry.After = json.RawMessage(before), json.RawMessage(after)
+
+		entries = append(entries, &entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := domain.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return entries, &metadata, nil
+}
+
+// auditPayload marshals the payload to JSON, nil payloads are stored as NULL.
+func auditPayload(payload any) (any, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
@@ -14,10 +14,11 @@ func (s *Storage) GetClubByID(ctx context.Context, clubID int64) (*domain.Club,
 	const op = "storage.postgresql.GetClubByID"
 
 	clubQuery := `
-        SELECT id, name, description, type, logo_url, banner_url, created_at, COUNT(user_id) as member_count
+        SELECT id, name, COALESCE(owner_id, 0), description, type, logo_url, banner_url, status, join_policy,
+               COALESCE(max_members, 0), created_at, COUNT(user_id) as member_count
         FROM clubs
         LEFT JOIN clubs_users ON clubs.id = clubs_users.club_id
-        WHERE clubs.id = $1 AND approved
+        WHERE clubs.id = $1
         GROUP BY clubs.id;
     `
 
@@ -25,10 +26,14 @@ func (s *Storage) GetClubByID(ctx context.Context, clubID int64) (*domain.Club,
 	err := s.DB.QueryRowContext(ctx, clubQuery, clubID).Scan(
 		&club.ID,
 		&club.Name,
+		&club.OwnerID,
 		&club.Description,
 		&club.ClubType,
 		&club.LogoURL,
 		&club.BannerURL,
+		&club.Status,
+		&club.JoinPolicy,
+		&club.MaxMembers,
 		&club.CreatedAt,
 		&club.NumOFMembers,
 	)
@@ -40,7 +45,7 @@ func (s *Storage) GetClubByID(ctx context.Context, clubID int64) (*domain.Club,
 	}
 
 	rolesQuery := `
-        SELECT id, name, permissions, position, color
+        SELECT id, name, permissions, position, color, is_default
         FROM roles
         WHERE club_id = $1;
     `
@@ -53,7 +58,7 @@ func (s *Storage) GetClubByID(ctx context.Context, clubID int64) (*domain.Club,
 	var roles []domain.Role
 	for rolesRows.Next() {
 		var r domain.Role
-		err = rolesRows.Scan(&r.ID, &r.Name, &r.Permissions.PermissionsHex, &r.Position, &r.Color)
+		err = rolesRows.Scan(&r.ID, &r.Name, &r.Permissions.PermissionsHex, &r.Position, &r.Color, &r.IsDefault)
 		if err != nil {
 			return nil, fmt.Errorf("%s: scanning roles: %w", op, err)
 		}
@@ -85,7 +90,7 @@ func (s *Storage) ListClubs(
 				( (STRPOS(LOWER(c.name), LOWER($1)) > 0 OR $1 = '') OR
 				(STRPOS(LOWER(c.description), LOWER($1)) > 0 OR $1 = '') )
 				AND	(type = ANY($2) OR $2::text[] IS NULL)
-				AND c.approved
+				AND c.status = 'active'
 			GROUP BY c.id
 			ORDER BY c.id
 			LIMIT $3 OFFSET $4;
@@ -147,16 +152,16 @@ func (s *Storage) ListNotApprovedClubs(
 	stmt, err := s.DB.Prepare(`
 		SELECT count(*) OVER(), c.id, c.name,
 		       c.description, c.type, c.logo_url,
-		       c.banner_url, c.created_at, COUNT(ccr.user_id) as member_count,
+		       c.banner_url, c.created_at, COUNT(ccr.id) as member_count,
 		       u.id, u.email, u.barcode, u.first_name, u.last_name, u.avatar_url
 		FROM clubs c
 		JOIN create_club_requests ccr ON c.id = ccr.club_id
-		JOIN users u ON u.id = ccr.user_id
+		JOIN users u ON u.id = c.owner_id
 		WHERE  
 		    ( (STRPOS(LOWER(c.name), LOWER($1)) > 0 OR $1 = '') OR
 			(STRPOS(LOWER(c.description), LOWER($1)) > 0 OR $1 = '') )
 			AND	(type = ANY($2) OR $2::text[] IS NULL)
-			AND NOT c.approved
+			AND c.status = 'pending' AND ccr.status = 'pending'
 		GROUP BY c.id, u.id
 		ORDER BY c.id
 		LIMIT $3 OFFSET $4;
@@ -216,7 +221,7 @@ func (s *Storage) GetUserClubsByID(ctx context.Context, userID int64) ([]*domain
 		       c.banner_url, c.created_at, (SELECT COUNT(cu2.club_id)FROM clubs_users cu2 WHERE cu2.club_id = c.id GROUP BY cu2.club_id) as member_count
 		FROM clubs_users cu
 		JOIN clubs c ON c.id = cu.club_id
-		WHERE cu.user_id = $1
+		WHERE cu.user_id = $1 AND c.status = 'active'
 		GROUP BY c.id;
 	`)
 	if err != nil {
@@ -306,12 +311,12 @@ func (s *Storage) ListClubMembers(ctx context.Context, clubID int64, filters dom
 
 	for _, user := range users {
 		rolesQuery := `
-        SELECT id, name, permissions, position, color
+        SELECT id, name, permissions, position, color, is_default
         FROM users_roles ur 
         JOIN roles r ON ur.role_id = r.id
-        WHERE ur.user_id = $1;
+        WHERE ur.user_id = $1 AND r.club_id = $2;
     `
-		rolesRows, err := s.DB.QueryContext(ctx, rolesQuery, user.ID)
+		rolesRows, err := s.DB.QueryContext(ctx, rolesQuery, user.ID, clubID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("%s: querying roles: %w", op, err)
 		}
@@ -319,7 +324,7 @@ func (s *Storage) ListClubMembers(ctx context.Context, clubID int64, filters dom
 
 		for rolesRows.Next() {
 			var r domain.Role
-			err = rolesRows.Scan(&r.ID, &r.Name, &r.Permissions.PermissionsHex, &r.Position, &r.Color)
+			err = rolesRows.Scan(&r.ID, &r.Name, &r.Permissions.PermissionsHex, &r.Position, &r.Color, &r.IsDefault)
 			if err != nil {
 				return nil, nil, fmt.Errorf("%s: scanning roles: %w", op, err)
 			}
@@ -343,7 +348,8 @@ func (s *Storage) ListClubJoinReq(ctx context.Context, clubID int64, filters dom
 		SELECT count(*) OVER(), u.id, u.email, u.barcode, u.first_name, u.last_name, u.avatar_url
 		FROM join_club_requests jcr 
 		JOIN users u ON u.id = jcr.user_id
-		WHERE jcr.club_id = $1
+		WHERE jcr.club_id = $1 AND jcr.status = 'pending'
+		ORDER BY jcr.request_time, jcr.id
 		LIMIT $2 OFFSET $3;
 	`
 
@@ -377,3 +383,107 @@ func (s *Storage) ListClubJoinReq(ctx context.Context, clubID int64, filters dom
 
 	return users, &metadata, nil
 }
+
+// ListUserClubApplications returns every club application of the user, the latest first.
+func (s *Storage) ListUserClubApplications(ctx context.Context, userID int64, filters domain.Filters) (
+	[]*domain.ClubApplication,
+	*domain.Metadata,
+	error,
+) {
+	const op = "storage.postgresql.ListUserClubApplications"
+
+	query := `
+		SELECT count(*) OVER(), ccr.id, ccr.club_id, c.name, ccr.user_id, ccr.status,
+		       COALESCE(ccr.reviewer_id, 0), ccr.reason, ccr.request_time, ccr.decided_at
+		FROM create_club_requests ccr
+		JOIN clubs c ON c.id = ccr.club_id
+		WHERE ccr.user_id = $1
+		ORDER BY ccr.request_time DESC, ccr.id DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, userID, filters.Limit(), filters.Offset())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totalRecords int32
+	applications := []*domain.ClubApplication{}
+
+	for rows.Next() {
+		var application domain.ClubApplication
+
+		err = rows.Scan(
+			&totalRecords, &application.ID, &application.ClubID, &application.ClubName, &application.UserID,
+			&application.Status, &application.ReviewerID, &application.Reason, &application.RequestedAt, &application.DecidedAt,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		applications = append(applications, &application)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := domain.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return applications, &metadata, nil
+}
+
+// ListUserJoinRequests returns every join request of the user, the latest first.
+func (s *Storage) ListUserJoinRequests(ctx context.Context, userID int64, filters domain.Filters) (
+	[]*domain.JoinRequest,
+	*domain.Metadata,
+	error,
+) {
+	const op = "storage.postgresql.ListUserJoinRequests"
+
+	query := `
+		SELECT count(*) OVER(), jcr.id, jcr.club_id, c.name, jcr.user_id, jcr.status, jcr.message,
+		       COALESCE(jcr.reviewer_id, 0), jcr.note, jcr.request_time, jcr.decided_at
+		FROM join_club_requests jcr
+		JOIN clubs c ON c.id = jcr.club_id
+		WHERE jcr.user_id = $1
+		ORDER BY jcr.request_time DESC, jcr.id DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, userID, filters.Limit(), filters.Offset())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totalRecords int32
+	requests := []*domain.JoinRequest{}
+
+	for rows.Next() {
+		var request domain.JoinRequest
+
+		err = rows.Scan(
+			&totalRecords, &request.ID, &request.ClubID, &request.ClubName, &request.UserID, &request.Status,
+			&request.Message, &request.ReviewerID, &request.Note, &request.RequestedAt, &request.DecidedAt,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		requests = append(requests, &request)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := domain.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return requests, &metadata, nil
+}
@@ -0,0 +1,356 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+// SaveInvitation stores the invitation of an active club and returns it with ID and CreatedAt set.
+// Direct invitations can not be sent to members and are announced with an outbox event.
+// The invitation is audited with its creator as the actor.
+func (s *Storage) SaveInvitation(ctx context.Context, invitation domain.Invitation) (*domain.Invitation, error) {
+	const op = "storage.postgresql.SaveInvitation"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var (
+		status   domain.ClubStatus
+		isMember bool
+	)
+	checkQuery := `
+		SELECT c.status, EXISTS(SELECT 1 FROM clubs_users cu WHERE cu.club_id = c.id AND cu.user_id = $2)
+		FROM clubs c
+		WHERE c.id = $1
+		FOR SHARE;
+	`
+	err = tx.QueryRowContext(ctx, checkQuery, invitation.ClubID, invitation.InviteeID).Scan(&status, &isMember)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+		}
+		return nil, fmt.Errorf("%s: failed to check club: %w", op, err)
+	}
+	if status != domain.ClubStatusActive {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrClubNotActive)
+	}
+	if isMember {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrUserIsClubMember)
+	}
+
+	insertQuery := `
+		INSERT INTO club_invitations(club_id, code, invitee_id, created_by, max_uses, expires_at)
+		VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), NULLIF($5, 0), $6)
+		RETURNING id, created_at;
+	`
+	err = tx.QueryRowContext(
+		ctx, insertQuery,
+		invitation.ClubID, invitation.Code, invitation.InviteeID, invitation.CreatedBy,
+		invitation.MaxUses, invitation.ExpiresAt,
+	).Scan(&invitation.ID, &invitation.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrUserNotExists)
+		}
+		return nil, fmt.Errorf("%s: failed to insert invitation: %w", op, err)
+	}
+
+	if invitation.IsDirect() {
+		err = insertOutboxEvent(ctx, tx, domain.Event{
+			RoutingKey: domain.EventMemberInvited,
+			Payload: domain.InvitationEvent{
+				ClubID:       invitation.ClubID,
+				InvitationID: invitation.ID,
+				InviteeID:    invitation.InviteeID,
+				ActorID:      invitation.CreatedBy,
+				Code:         invitation.Code,
+				ExpiresAt:    invitation.ExpiresAt,
+				OccurredAt:   time.Now().UTC(),
+			},
+		})
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = insertAuditEntry(ctx, tx, domain.AuditEntry{
+		ClubID:   invitation.ClubID,
+		ActorID:  invitation.CreatedBy,
+		TargetID: invitation.InviteeID,
+		Action:   domain.AuditInvitationCreated,
+		After: map[string]any{
+			"invitation_id": invitation.ID,
+			"max_uses":      invitation.MaxUses,
+			"expires_at":    invitation.ExpiresAt,
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return &invitation, nil
+}
+
+func (s *Storage) ListInvitations(ctx context.Context, clubID int64, filters domain.Filters) (
+	[]*domain.Invitation,
+	*domain.Metadata,
+	error,
+) {
+	const op = "storage.postgresql.ListInvitations"
+
+	query := `
+		SELECT count(*) OVER(), id, club_id, code, COALESCE(invitee_id, 0), COALESCE(created_by, 0),
+		       COALESCE(max_uses, 0), uses, expires_at, revoked_at, created_at
+		FROM club_invitations
+		WHERE club_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, clubID, filters.Limit(), filters.Offset())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totalRecords int32
+	invitations := []*domain.Invitation{}
+
+	for rows.Next() {
+		var invitation domain.Invitation
+
+		err = rows.Scan(
+			&totalRecords, &invitation.ID, &invitation.ClubID, &invitation.Code, &invitation.InviteeID,
+			&invitation.CreatedBy, &invitation.MaxUses, &invitation.Uses, &invitation.ExpiresAt,
+			&invitation.RevokedAt, &invitation.CreatedAt,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		invitations = append(invitations, &invitation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := domain.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return invitations, &metadata, nil
+}
+
+func (s *Storage) RevokeInvitation(ctx context.Context, clubID, invitationID int64, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.RevokeInvitation"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	query := `
+		UPDATE club_invitations
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND club_id = $2 AND revoked_at IS NULL;
+	`
+	result, err := tx.ExecContext(ctx, query, invitationID, clubID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to revoke invitation: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrInvitationNotExists)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// RedeemInvitation makes the user a member of the club the invitation with code belongs to and
+// counts the use. An open join request of the user is approved on behalf of the inviter.
+// Invitations do not waitlist, storage.ErrClubFull is returned if the club is full.
+// Direct invitations of other users are reported as not existing. The use is audited with the user as the actor.
+func (s *Storage) RedeemInvitation(ctx context.Context, code string, userID int64) (*domain.Invitation, error) {
+	const op = "storage.postgresql.RedeemInvitation"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var (
+		invitation domain.Invitation
+		now        time.Time
+	)
+	invitationQuery := `
+		SELECT id, club_id, code, COALESCE(invitee_id, 0), COALESCE(created_by, 0),
+		       COALESCE(max_uses, 0), uses, expires_at, revoked_at, created_at, CURRENT_TIMESTAMP::TIMESTAMP
+		FROM club_invitations
+		WHERE code = $1
+		FOR UPDATE;
+	`
+	err = tx.QueryRowContext(ctx, invitationQuery, code).Scan(
+		&invitation.ID, &invitation.ClubID, &invitation.Code, &invitation.InviteeID, &invitation.CreatedBy,
+		&invitation.MaxUses, &invitation.Uses, &invitation.ExpiresAt, &invitation.RevokedAt, &invitation.CreatedAt,
+		&now,
+	)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvitationNotExists)
+		}
+		return nil, fmt.Errorf("%s: failed to get invitation: %w", op, err)
+	}
+	if invitation.IsDirect() && invitation.InviteeID != userID {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrInvitationNotExists)
+	}
+	if !invitation.CanBeRedeemedBy(userID, now) {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrInvitationNotValid)
+	}
+
+	var (
+		status     domain.ClubStatus
+		joinPolicy domain.JoinPolicy
+		isBanned   bool
+		isMember   bool
+	)
+	checkQuery := `
+		SELECT c.status, c.join_policy, EXISTS(
+			SELECT 1 FROM club_bans b
+			WHERE b.club_id = c.id AND b.user_id = $1 AND (b.expires_at IS NULL OR b.expires_at > CURRENT_TIMESTAMP)
+		), EXISTS(
+			SELECT 1 FROM clubs_users cu WHERE cu.club_id = c.id AND cu.user_id = $1
+		)
+		FROM clubs c
+		WHERE c.id = $2
+		FOR NO KEY UPDATE;
+	`
+	err = tx.QueryRowContext(ctx, checkQuery, userID, invitation.ClubID).Scan(&status, &joinPolicy, &isBanned, &isMember)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to check club: %w", op, err)
+	}
+	switch {
+	case status != domain.ClubStatusActive:
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrClubNotActive)
+	case joinPolicy == domain.JoinPolicyClosed:
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrClubClosed)
+	case isBanned:
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrUserBanned)
+	case isMember:
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrUserIsClubMember)
+	}
+
+	err = reserveSeat(ctx, tx, invitation.ClubID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE club_invitations SET uses = uses + 1 WHERE id = $1;`, invitation.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to count invitation use: %w", op, err)
+	}
+	invitation.Uses++
+
+	_, err = tx.ExecContext(
+		ctx, decideJoinRequestQuery,
+		invitation.ClubID, userID, domain.JoinRequestStatusApproved, invitation.CreatedBy, "",
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to approve join request: %w", op, err)
+	}
+
+	err = insertMember(ctx, tx, invitation.ClubID, userID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, domain.AuditEntry{
+		ClubID:   invitation.ClubID,
+		ActorID:  userID,
+		TargetID: userID,
+		Action:   domain.AuditInvitationRedeemed,
+		After:    map[string]any{"invitation_id": invitation.ID, "invited_by": invitation.CreatedBy},
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return &invitation, nil
+}
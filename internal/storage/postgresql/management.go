@@ -2,16 +2,33 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"strconv"
+	"time"
 )
 
-func (s *Storage) SaveClub(ctx context.Context, dto dtos.CreateClubDTO) error {
+// decideApplicationQuery records the decision on the pending application of the club and returns its id.
+// The applicant is not returned, it is gone once their user is deleted and the club is owned by a successor.
+const decideApplicationQuery = `
+	UPDATE create_club_requests
+	SET status = $2, reviewer_id = NULLIF($3, 0), reason = $4, decided_at = CURRENT_TIMESTAMP
+	WHERE club_id = $1 AND status = 'pending'
+	RETURNING id;
+`
+
+// SaveClub inserts the pending club with its application and returns the club id,
+// the audit entry is recorded with ClubID set to it.
+func (s *Storage) SaveClub(ctx context.Context, dto dtos.CreateClubDTO, audit domain.AuditEntry) (int64, error) {
 	const op = "storage.postgresql.SaveClub"
 
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
 	}
 
 	// Defer the rollback in case of any error.
@@ -34,14 +51,138 @@ func (s *Storage) SaveClub(ctx context.Context, dto dtos.CreateClubDTO) error {
 	).Scan(&clubID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s, failed to insert into clubs and get club_id: %w", op, err)
+		return 0, fmt.Errorf("%s, failed to insert into clubs and get club_id: %w", op, err)
 	}
 
 	// Insert into the requests_create_club table.
 	_, err = tx.ExecContext(ctx, "INSERT INTO create_club_requests (club_id, user_id) VALUES ($1, $2)", clubID, dto.OwnerID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert create club request: %w", op, err)
+		return 0, fmt.Errorf("%s: failed to insert create club request: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventClubCreated,
+		Payload: domain.ClubEvent{
+			ClubID:     clubID,
+			OwnerID:    dto.OwnerID,
+			Name:       dto.Name,
+			ClubType:   dto.ClubType,
+			OccurredAt: time.Now().UTC(),
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	audit.ClubID = clubID
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Commit the transaction.
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return clubID, nil
+}
+
+// ApproveClub activates the club, creates roles from templates and makes the owner a member
+// with the default role and every role marked for the owner.
+func (s *Storage) ApproveClub(
+	ctx context.Context,
+	clubID, reviewerID int64,
+	templates []domain.RoleTemplate,
+	audit domain.AuditEntry,
+) error {
+	const op = "storage.postgresql.ApproveClub"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var applicationID int64
+	// Record the decision on the pending application
+	err = tx.QueryRowContext(ctx, decideApplicationQuery, clubID, domain.ApplicationStatusApproved, reviewerID, "").Scan(&applicationID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+		}
+		return fmt.Errorf("%s: failed to update create club request: %w", op, err)
+	}
+
+	var userID int64
+	// Move club from pending to active, the current owner becomes its first member
+	err = tx.QueryRowContext(
+		ctx,
+		`UPDATE clubs SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = $3 RETURNING owner_id`,
+		clubID, domain.ClubStatusActive, domain.ClubStatusPending,
+	).Scan(&userID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+		}
+		return fmt.Errorf("%s: failed to update club status to active: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO clubs_users(user_id, club_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userID, clubID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to insert to clubs_users: %w", op, err)
+	}
+
+	// Seed default roles
+	for _, t := range templates {
+		var roleID int
+		err = tx.QueryRowContext(
+			ctx,
+			`INSERT INTO roles(club_id, name, permissions, position, color, is_default, is_owner_role) VALUES ($1, $2, $3, $4, $5, $6, $7) returning id`,
+			clubID, t.Name, strconv.FormatUint(t.Permissions, 10), t.Position, t.Color, t.IsDefault, t.ForOwner,
+		).Scan(&roleID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to insert %s role: %w", op, t.Name, err)
+		}
+
+		if !t.IsDefault && !t.ForOwner {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO users_roles(user_id, role_id) VALUES ($1, $2)`, userID, roleID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to insert to users_roles: %w", op, err)
+		}
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventClubApproved,
+		Payload:    domain.ClubEvent{ClubID: clubID, OwnerID: userID, ReviewerID: reviewerID, OccurredAt: time.Now().UTC()},
+	})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Commit the transaction.
@@ -52,8 +193,9 @@ func (s *Storage) SaveClub(ctx context.Context, dto dtos.CreateClubDTO) error {
 	return nil
 }
 
-func (s *Storage) ApproveClub(ctx context.Context, clubID int64) error {
-	const op = "storage.postgresql.ApproveClub"
+// RejectClub rejects the club and records the decision with reason on its pending application.
+func (s *Storage) RejectClub(ctx context.Context, clubID, reviewerID int64, reason string, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.RejectClub"
 
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -68,55 +210,174 @@ func (s *Storage) ApproveClub(ctx context.Context, clubID int64) error {
 		}
 	}()
 
-	var (
-		userID int64
-		roleID int
-	)
-	// Delete create club request
-	err = tx.QueryRowContext(ctx, `DELETE FROM create_club_requests WHERE club_id = $1 RETURNING user_id`, clubID).Scan(&userID)
+	var applicationID int64
+	// Record the decision on the pending application
+	err = tx.QueryRowContext(ctx, decideApplicationQuery, clubID, domain.ApplicationStatusRejected, reviewerID, reason).Scan(&applicationID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+		}
+		return fmt.Errorf("%s: failed to update create club request: %w", op, err)
+	}
+
+	var ownerID int64
+	// Move club from pending to rejected
+	err = tx.QueryRowContext(
+		ctx,
+		`UPDATE clubs SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = $3 RETURNING COALESCE(owner_id, 0)`,
+		clubID, domain.ClubStatusRejected, domain.ClubStatusPending,
+	).Scan(&ownerID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+		}
+		return fmt.Errorf("%s: failed to update club status to rejected: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventClubRejected,
+		Payload: domain.ClubEvent{
+			ClubID:     clubID,
+			OwnerID:    ownerID,
+			ReviewerID: reviewerID,
+			Reason:     reason,
+			OccurredAt: time.Now().UTC(),
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to delete create club request and get userID: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Update club approved to true
-	result, err := tx.ExecContext(ctx, `UPDATE clubs SET approved = true WHERE id = $1 and not approved`, clubID)
+	// Commit the transaction.
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateClub applies the non-nil fields of dto to the active club.
+func (s *Storage) UpdateClub(ctx context.Context, dto dtos.UpdateClubDTO, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.UpdateClub"
+
+	query := `
+		UPDATE clubs
+		SET name = COALESCE($2, name),
+		    description = COALESCE($3, description),
+		    type = COALESCE($4, type),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'active';
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, query, dto.ClubID, dto.Name, dto.Description, dto.ClubType)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to update club approved to true: %w", op, err)
+		return fmt.Errorf("%s: failed to update club: %w", op, err)
 	}
-	// Check the number of rows affected
+
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		tx.Rollback()
 		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
 	}
-
 	if rowsAffected == 0 {
 		tx.Rollback()
-		return fmt.Errorf("%s: no rows updated, club may already be approved or does not exist", op)
+		return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
 	}
 
-	// New president role
-	err = tx.QueryRowContext(ctx, `INSERT INTO roles(club_id, name, permissions, position, color) VALUES ($1, $2, '0', 0, 8223868) returning id`, clubID, "member").Scan(&roleID)
+	err = insertAuditEntry(ctx, tx, audit)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert president role: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
 	}
 
-	_, err = tx.ExecContext(ctx, `INSERT INTO clubs_users(user_id, club_id) VALUES ($1, $2)`, userID, clubID)
+	return nil
+}
+
+// SetJoinPolicy changes the join policy of the active club. Opening the club admits the pending
+// join requests that queue for its free seats.
+func (s *Storage) SetJoinPolicy(ctx context.Context, clubID int64, policy domain.JoinPolicy, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.SetJoinPolicy"
+
+	query := `
+		UPDATE clubs
+		SET join_policy = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'active';
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, query, clubID, policy)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert to clubs_users: %w", op, err)
+		return fmt.Errorf("%s: failed to update join policy: %w", op, err)
 	}
 
-	_, err = tx.ExecContext(ctx, `INSERT INTO users_roles(user_id, role_id) VALUES ($1, $2)`, userID, roleID)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert to users_roles: %w", op, err)
+		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = promoteWaitlist(ctx, tx, clubID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Commit the transaction.
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
 	}
@@ -124,8 +385,19 @@ func (s *Storage) ApproveClub(ctx context.Context, clubID int64) error {
 	return nil
 }
 
-func (s *Storage) RejectClub(ctx context.Context, clubID int64) error {
-	const op = "storage.postgresql.RejectClub"
+// SetMaxMembers limits the number of members of the active club, nil removes the limit. Raising or
+// removing the limit admits the queued applicants to the new seats, lowering it keeps the current members.
+func (s *Storage) SetMaxMembers(ctx context.Context, clubID int64, maxMembers *int, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.SetMaxMembers"
+
+	query := `
+		UPDATE clubs
+		SET max_members = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'active';
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
 
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -140,33 +412,306 @@ func (s *Storage) RejectClub(ctx context.Context, clubID int64) error {
 		}
 	}()
 
-	// Delete create club request
-	_, err = tx.ExecContext(ctx, `DELETE FROM create_club_requests WHERE club_id = $1`, clubID)
+	result, err := tx.ExecContext(ctx, query, clubID, maxMembers)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to update max members: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// A lowered limit leaves the club full, so nobody is promoted.
+	err = promoteWaitlist(ctx, tx, clubID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to delete create club request: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Delete club
-	result, err := tx.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1 and not approved`, clubID)
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetClubStatus moves the club from status from to status to.
+// It returns storage.ErrClubStatusChanged if the club is no longer in status from.
+func (s *Storage) SetClubStatus(ctx context.Context, clubID int64, from, to domain.ClubStatus, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.SetClubStatus"
+
+	query := `
+		UPDATE clubs
+		SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $2;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, query, clubID, from, to)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to delete club: %w", op, err)
+		return fmt.Errorf("%s: failed to update club status: %w", op, err)
 	}
 
-	// Check the number of rows affected
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to get rows affected from delete: %w", op, err)
+		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
 	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateClubImage(ctx context.Context, clubID int64, kind domain.ImageKind, url string, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.UpdateClubImage"
 
+	var query string
+	switch kind {
+	case domain.ImageKindLogo:
+		query = `UPDATE clubs SET logo_url = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'active';`
+	case domain.ImageKindBanner:
+		query = `UPDATE clubs SET banner_url = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'active';`
+	default:
+		return fmt.Errorf("%s: unknown image kind: %s", op, kind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, query, clubID, url)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to update club image: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to get rows affected from update: %w", op, err)
+	}
 	if rowsAffected == 0 {
 		tx.Rollback()
-		return fmt.Errorf("%s: no rows deleted, club may not exist", op)
+		return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// TransferOwnership makes toUserID the owner of the club, moves the owner roles to them and records the transfer.
+// It returns storage.ErrClubOwnerChanged if fromUserID is no longer the owner.
+func (s *Storage) TransferOwnership(ctx context.Context, clubID, fromUserID, toUserID, actorID int64, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.TransferOwnership"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var ownerID int64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(owner_id, 0) FROM clubs WHERE id = $1 FOR UPDATE;`, clubID).Scan(&ownerID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+		}
+		return fmt.Errorf("%s: failed to get club owner: %w", op, err)
+	}
+	if ownerID != fromUserID {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrClubOwnerChanged)
+	}
+
+	var isMember bool
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS(SELECT 1 FROM clubs_users WHERE club_id = $1 AND user_id = $2);`,
+		clubID, toUserID,
+	).Scan(&isMember)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to check membership: %w", op, err)
+	}
+	if !isMember {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrUserNotClubMember)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE clubs SET owner_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`, clubID, toUserID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to update club owner: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO club_ownership_transfers(club_id, from_user_id, to_user_id, transferred_by) VALUES ($1, $2, $3, $4);`,
+		clubID, fromUserID, toUserID, actorID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to insert ownership transfer: %w", op, err)
+	}
+
+	err = handOverOwnerRoles(ctx, tx, clubID, fromUserID, toUserID, actorID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResubmitClub moves the rejected club back to pending with updated details and opens a new application.
+func (s *Storage) ResubmitClub(ctx context.Context, dto dtos.UpdateClubDTO, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.ResubmitClub"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	query := `
+		UPDATE clubs
+		SET name = COALESCE($3, name),
+		    description = COALESCE($4, description),
+		    type = COALESCE($5, type),
+		    status = $6,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND owner_id = $2 AND status = $7
+		RETURNING name, type;
+	`
+	var name, clubType string
+	err = tx.QueryRowContext(
+		ctx, query,
+		dto.ClubID, dto.UserID, dto.Name, dto.Description, dto.ClubType,
+		domain.ClubStatusPending, domain.ClubStatusRejected,
+	).Scan(&name, &clubType)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubStatusChanged)
+		}
+		return fmt.Errorf("%s: failed to update club: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO create_club_requests (club_id, user_id) VALUES ($1, $2);`, dto.ClubID, dto.UserID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to insert create club request: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventClubResubmitted,
+		Payload: domain.ClubEvent{
+			ClubID:     dto.ClubID,
+			OwnerID:    dto.UserID,
+			Name:       name,
+			ClubType:   clubType,
+			OccurredAt: time.Now().UTC(),
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Commit the transaction.
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
 	}
@@ -2,42 +2,468 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"github.com/jackc/pgx/v5/pgconn"
 	"time"
 )
 
-func (s *Storage) InsertJoinRequest(ctx context.Context, userID, clubID int64) error {
+// decideJoinRequestQuery records the decision on the pending or waitlisted join request of the user.
+const decideJoinRequestQuery = `
+	UPDATE join_club_requests
+	SET status = $3, reviewer_id = NULLIF($4, 0), note = $5, decided_at = CURRENT_TIMESTAMP
+	WHERE club_id = $1 AND user_id = $2 AND status IN ('pending', 'waitlisted');
+`
+
+// InsertJoinRequest creates a pending join request with the applicant message and returns its status.
+// Requests to open clubs are approved in the same transaction, or waitlisted if the club is full.
+// Both the request and the decision are recorded in the audit log with the user as the actor.
+// Members, banned users and users who already have an open request of the club can not request
+// to join, and neither can anyone to invite-only or closed clubs.
+func (s *Storage) InsertJoinRequest(ctx context.Context, userID, clubID int64, message string) (domain.JoinRequestStatus, error) {
 	const op = "storage.postgresql.InsertJoinRequest"
 
-	stmt, err := s.DB.Prepare(`INSERT INTO join_club_requests(user_id, club_id) VALUES ($1, $2)`)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: failed to begin transaction: %w", op, err)
 	}
 
-	defer stmt.Close()
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
 
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
-	defer cancel()
+	var (
+		status     domain.ClubStatus
+		joinPolicy domain.JoinPolicy
+		isBanned   bool
+		isMember   bool
+	)
+	checkQuery := `
+		SELECT c.status, c.join_policy, EXISTS(
+			SELECT 1 FROM club_bans b
+			WHERE b.club_id = c.id AND b.user_id = $1 AND (b.expires_at IS NULL OR b.expires_at > CURRENT_TIMESTAMP)
+		), EXISTS(
+			SELECT 1 FROM clubs_users cu WHERE cu.club_id = c.id AND cu.user_id = $1
+		)
+		FROM clubs c
+		WHERE c.id = $2
+		FOR NO KEY UPDATE;
+	`
+	err = tx.QueryRowContext(ctx, checkQuery, userID, clubID).Scan(&status, &joinPolicy, &isBanned, &isMember)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+		}
+		return "", fmt.Errorf("%s: failed to check club: %w", op, err)
+	}
+	switch {
+	case status != domain.ClubStatusActive:
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, storage.ErrClubNotActive)
+	case isBanned:
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, storage.ErrUserBanned)
+	case isMember:
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, storage.ErrUserIsClubMember)
+	case joinPolicy == domain.JoinPolicyInviteOnly:
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, storage.ErrClubInviteOnly)
+	case !joinPolicy.AcceptsJoinRequests():
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, storage.ErrClubClosed)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO join_club_requests(user_id, club_id, message) VALUES ($1, $2, $3)`,
+		userID, clubID, message,
+	)
+	if err != nil {
+		tx.Rollback()
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrJoinRequestExists)
+		}
+		return "", fmt.Errorf("%s: failed to insert join request: %w", op, err)
+	}
 
-	result, err := stmt.ExecContext(ctx, userID, clubID)
+	err = insertAuditEntry(ctx, tx, domain.AuditEntry{
+		ClubID:   clubID,
+		ActorID:  userID,
+		TargetID: userID,
+		Action:   domain.AuditJoinRequested,
+		After:    map[string]any{"message": message},
+	})
 	if err != nil {
-		return fmt.Errorf("%s: failed to execute query: %w", op, err)
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	requestStatus := domain.JoinRequestStatusPending
+	if joinPolicy.ApprovesJoinRequests() {
+		// Open clubs approve the request on behalf of nobody.
+		requestStatus, err = decideJoinRequest(ctx, tx, clubID, userID, 0, domain.JoinRequestStatusApproved, "")
+		if err != nil {
+			tx.Rollback()
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return requestStatus, nil
+}
+
+// AddNewMember approves the pending join request of the user on behalf of reviewerID and
+// makes the user a member with the default role of the club. If the club is full the request
+// is waitlisted instead, the resulting status of the request is returned.
+func (s *Storage) AddNewMember(ctx context.Context, clubID, userID, reviewerID int64) (domain.JoinRequestStatus, error) {
+	const op = "storage.postgresql.AddNewMember"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	status, err := decideJoinRequest(ctx, tx, clubID, userID, reviewerID, domain.JoinRequestStatusApproved, "")
+	if err != nil {
+		tx.Rollback()
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return status, nil
+}
+
+// reserveSeat locks the club row against concurrent admissions and status changes until tx ends.
+// It returns storage.ErrClubNotActive if the club is not active and storage.ErrClubFull if the club
+// has reached its max members.
+func reserveSeat(ctx context.Context, tx *sql.Tx, clubID int64) error {
+	var club domain.Club
+	err := tx.QueryRowContext(
+		ctx, `SELECT status, COALESCE(max_members, 0) FROM clubs WHERE id = $1 FOR NO KEY UPDATE;`, clubID,
+	).Scan(&club.Status, &club.MaxMembers)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrClubNotExists
+		}
+		return fmt.Errorf("failed to lock club: %w", err)
+	}
+	if club.Status != domain.ClubStatusActive {
+		return storage.ErrClubNotActive
+	}
+	if club.MaxMembers == 0 {
+		return nil
+	}
+
+	// The count runs after the lock is acquired, so it sees members added by the previous holder.
+	var members int
+	err = tx.QueryRowContext(ctx, `SELECT count(*) FROM clubs_users WHERE club_id = $1;`, clubID).Scan(&members)
+	if err != nil {
+		return fmt.Errorf("failed to count members: %w", err)
+	}
+	if club.IsFull(members) {
+		return storage.ErrClubFull
+	}
+
+	return nil
+}
+
+// promoteWaitlist admits the users with the oldest queued join requests of the club within tx while it has
+// free seats. Waitlisted requests are always queued, pending ones only if the join policy of the club approves
+// them without a reviewer. The approvals are audited with the reviewer of the request as the actor, if any.
+// Nobody is admitted to clubs that are not active.
+func promoteWaitlist(ctx context.Context, tx *sql.Tx, clubID int64) error {
+	promoteQuery := `
+		UPDATE join_club_requests
+		SET status = 'approved', decided_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM join_club_requests
+			WHERE club_id = $1 AND status = ANY($2)
+			ORDER BY request_time, id
+			LIMIT 1
+		)
+		RETURNING user_id, COALESCE(reviewer_id, 0);
+	`
+	var statuses []string
+	for {
+		err := reserveSeat(ctx, tx, clubID)
+		if errors.Is(err, storage.ErrClubFull) || errors.Is(err, storage.ErrClubNotActive) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The policy is read once the club row is locked by reserveSeat.
+		if statuses == nil {
+			var joinPolicy domain.JoinPolicy
+			err = tx.QueryRowContext(ctx, `SELECT join_policy FROM clubs WHERE id = $1;`, clubID).Scan(&joinPolicy)
+			if err != nil {
+				return fmt.Errorf("failed to get join policy: %w", err)
+			}
+			for _, status := range joinPolicy.QueuedStatuses() {
+				statuses = append(statuses, string(status))
+			}
+		}
+
+		var userID, reviewerID int64
+		err = tx.QueryRowContext(ctx, promoteQuery, clubID, statuses).Scan(&userID, &reviewerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to promote join request: %w", err)
+		}
+
+		err = insertMember(ctx, tx, clubID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = insertAuditEntry(ctx, tx, domain.AuditEntry{
+			ClubID:   clubID,
+			ActorID:  reviewerID,
+			TargetID: userID,
+			Action:   domain.AuditMembershipApproved,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// insertMember adds the user to the club with the default role of the club within tx
+// and writes the member joined event to the outbox.
+func insertMember(ctx context.Context, tx *sql.Tx, clubID, userID int64) error {
+	result, err := tx.ExecContext(ctx, `INSERT INTO clubs_users(user_id, club_id) VALUES ($1, $2);`, userID, clubID)
+	if err != nil {
+		return fmt.Errorf("failed to insert to clubs_users: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%s: failed to get rows affected from insert: %w", op, err)
+		return fmt.Errorf("failed to get rows affected from insert into clubs_users: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("no rows inserted into clubs_users")
+	}
+
+	var roleID int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE club_id = $1 AND is_default;`, clubID).Scan(&roleID)
+	if err != nil {
+		return fmt.Errorf("failed to get member role id of club: %w", err)
+	}
+
+	result, err = tx.ExecContext(ctx, `INSERT INTO users_roles(user_id, role_id) VALUES ($1, $2);`, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to insert to users_roles: %w", err)
 	}
 
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected from insert into users_roles: %w", err)
+	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: no row inserted", op)
+		return errors.New("no rows inserted into users_roles")
+	}
+
+	return insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventMemberJoined,
+		Payload:    domain.MemberEvent{ClubID: clubID, UserID: userID, OccurredAt: time.Now().UTC()},
+	})
+}
+
+// RejectJoinRequest rejects the pending join request of the user on behalf of reviewerID with the note.
+func (s *Storage) RejectJoinRequest(ctx context.Context, clubID, userID, reviewerID int64, note string) error {
+	const op = "storage.postgresql.RejectJoinRequest"
+
+	err := s.closeJoinRequest(ctx, clubID, userID, reviewerID, domain.JoinRequestStatusRejected, note)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) AddNewMember(ctx context.Context, clubID, userID int64) error {
-	const op = "storage.postgresql.AddNewMember"
+// WithdrawJoinRequest marks the pending join request of the user as withdrawn by the user.
+func (s *Storage) WithdrawJoinRequest(ctx context.Context, clubID, userID int64) error {
+	const op = "storage.postgresql.WithdrawJoinRequest"
+
+	err := s.closeJoinRequest(ctx, clubID, userID, 0, domain.JoinRequestStatusWithdrawn, "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// closeJoinRequest records a decision that admits nobody on the join request of the user in its own transaction.
+func (s *Storage) closeJoinRequest(
+	ctx context.Context,
+	clubID, userID, reviewerID int64,
+	status domain.JoinRequestStatus,
+	note string,
+) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	_, err = decideJoinRequest(ctx, tx, clubID, userID, reviewerID, status, note)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	return nil
+}
+
+// DecideJoinRequests approves or rejects the pending join requests of users in a single transaction
+// and returns the outcome for every user. A failed request is rolled back to its savepoint and does
+// not affect the others, approved users become members the same way as in AddNewMember.
+func (s *Storage) DecideJoinRequests(
+	ctx context.Context,
+	clubID, reviewerID int64,
+	userIDs []int64,
+	status domain.JoinRequestStatus,
+	note string,
+) ([]domain.JoinRequestDecision, error) {
+	const op = "storage.postgresql.DecideJoinRequests"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	decisions := make([]domain.JoinRequestDecision, 0, len(userIDs))
+	for _, userID := range userIDs {
+		_, err = tx.ExecContext(ctx, `SAVEPOINT join_request;`)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: failed to create savepoint: %w", op, err)
+		}
+
+		decided, decisionErr := decideJoinRequest(ctx, tx, clubID, userID, reviewerID, status, note)
+		if decisionErr != nil {
+			_, err = tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT join_request;`)
+		} else {
+			_, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT join_request;`)
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: failed to finish savepoint: %w", op, err)
+		}
+
+		decisions = append(decisions, domain.JoinRequestDecision{UserID: userID, Status: decided, Err: decisionErr})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return decisions, nil
+}
+
+// decideJoinRequest records the decision on the join request of the user within tx and adds the user
+// to the club if it is approved. Approvals of a full club are waitlisted, the resulting status is returned.
+// The decision is audited with reviewerID as the actor, or the user if there is no reviewer.
+func decideJoinRequest(
+	ctx context.Context,
+	tx *sql.Tx,
+	clubID, userID, reviewerID int64,
+	status domain.JoinRequestStatus,
+	note string,
+) (domain.JoinRequestStatus, error) {
+	if status == domain.JoinRequestStatusApproved {
+		err := reserveSeat(ctx, tx, clubID)
+		switch {
+		case errors.Is(err, storage.ErrClubFull):
+			status = domain.JoinRequestStatusWaitlisted
+		case err != nil:
+			return "", err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, decideJoinRequestQuery, clubID, userID, status, reviewerID, note)
+	if err != nil {
+		return "", fmt.Errorf("failed to update join request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("failed to get rows affected from update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return "", storage.ErrJoinRequestNotExists
+	}
+
+	actorID := reviewerID
+	if actorID == 0 {
+		actorID = userID
+	}
+	entry := domain.AuditEntry{ClubID: clubID, ActorID: actorID, TargetID: userID, Action: status.AuditAction()}
+	if note != "" {
+		entry.After = map[string]any{"note": note}
+	}
+	err = insertAuditEntry(ctx, tx, entry)
+	if err != nil {
+		return "", err
+	}
+
+	if status != domain.JoinRequestStatusApproved {
+		return status, nil
+	}
+
+	return status, insertMember(ctx, tx, clubID, userID)
+}
+
+// DeleteMember removes the user from the club together with all of their club roles, writes the event
+// to the outbox and admits the oldest queued applicant to the free seat. The club owner can not be removed.
+func (s *Storage) DeleteMember(ctx context.Context, clubID, userID int64, event domain.Event, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.DeleteMember"
 
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -52,14 +478,34 @@ func (s *Storage) AddNewMember(ctx context.Context, clubID, userID int64) error
 		}
 	}()
 
-	deleteQuery := `
-		DELETE FROM join_club_requests 
-		WHERE club_id = $1 AND user_id = $2;
+	var ownerID int64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(owner_id, 0) FROM clubs WHERE id = $1 FOR NO KEY UPDATE;`, clubID).Scan(&ownerID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+		}
+		return fmt.Errorf("%s: failed to get club owner: %w", op, err)
+	}
+	if ownerID == userID {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrUserIsClubOwner)
+	}
+
+	deleteRolesQuery := `
+		DELETE FROM users_roles
+		WHERE user_id = $2 AND role_id IN (SELECT id FROM roles WHERE club_id = $1);
 	`
-	result, err := tx.ExecContext(ctx, deleteQuery, clubID, userID)
+	_, err = tx.ExecContext(ctx, deleteRolesQuery, clubID, userID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to delete from users_roles: %w", op, err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM clubs_users WHERE club_id = $1 AND user_id = $2;`, clubID, userID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to delete join request: %w", op, err)
+		return fmt.Errorf("%s: failed to delete from clubs_users: %w", op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -69,46 +515,129 @@ func (s *Storage) AddNewMember(ctx context.Context, clubID, userID int64) error
 	}
 	if rowsAffected == 0 {
 		tx.Rollback()
-		return fmt.Errorf("%s: no rows deleted from join requests", op)
+		return fmt.Errorf("%s: %w", op, storage.ErrUserNotClubMember)
 	}
 
-	result, err = tx.ExecContext(ctx, `INSERT INTO clubs_users(user_id, club_id) VALUES ($1, $2);`, userID, clubID)
+	err = insertOutboxEvent(ctx, tx, event)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert to clubs_users: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	rowsAffected, err = result.RowsAffected()
+	err = insertAuditEntry(ctx, tx, audit)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to get rows affected from insert into clubs_users: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
-	if rowsAffected == 0 {
+
+	err = promoteWaitlist(ctx, tx, clubID)
+	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: no rows inserted into clubs_users", op)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	var roleID int
-	err = tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE club_id = $1 AND name = 'member';`, clubID).Scan(&roleID)
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// BanMember records the ban, removes the user's membership and roles of the club and rejects
+// their open join request with the ban reason. A freed seat goes to the oldest queued applicant.
+// The club owner can not be banned.
+func (s *Storage) BanMember(ctx context.Context, ban domain.Ban, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.BanMember"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var ownerID int64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(owner_id, 0) FROM clubs WHERE id = $1 FOR NO KEY UPDATE;`, ban.ClubID).Scan(&ownerID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrClubNotExists)
+		}
+		return fmt.Errorf("%s: failed to get club owner: %w", op, err)
+	}
+	if ownerID == ban.UserID {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrUserIsClubOwner)
+	}
+
+	deleteRolesQuery := `
+		DELETE FROM users_roles
+		WHERE user_id = $2 AND role_id IN (SELECT id FROM roles WHERE club_id = $1);
+	`
+	_, err = tx.ExecContext(ctx, deleteRolesQuery, ban.ClubID, ban.UserID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to get member role id of club: %w", op, err)
+		return fmt.Errorf("%s: failed to delete from users_roles: %w", op, err)
 	}
 
-	result, err = tx.ExecContext(ctx, `INSERT INTO users_roles(user_id, role_id) VALUES ($1, $2);`, userID, roleID)
+	_, err = tx.ExecContext(ctx, `DELETE FROM clubs_users WHERE club_id = $1 AND user_id = $2;`, ban.ClubID, ban.UserID)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to insert to users_roles: %w", op, err)
+		return fmt.Errorf("%s: failed to delete from clubs_users: %w", op, err)
 	}
 
-	rowsAffected, err = result.RowsAffected()
+	_, err = tx.ExecContext(
+		ctx, decideJoinRequestQuery,
+		ban.ClubID, ban.UserID, domain.JoinRequestStatusRejected, ban.BannedBy, ban.Reason,
+	)
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: failed to get rows affected from insert into users_roles: %w", op, err)
+		return fmt.Errorf("%s: failed to reject join request: %w", op, err)
 	}
-	if rowsAffected == 0 {
+
+	insertBanQuery := `
+		INSERT INTO club_bans(club_id, user_id, banned_by, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (club_id, user_id) DO UPDATE
+		SET banned_by = EXCLUDED.banned_by, reason = EXCLUDED.reason,
+		    expires_at = EXCLUDED.expires_at, created_at = CURRENT_TIMESTAMP;
+	`
+	_, err = tx.ExecContext(ctx, insertBanQuery, ban.ClubID, ban.UserID, ban.BannedBy, ban.Reason, ban.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to insert into club_bans: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{
+		RoutingKey: domain.EventMemberBanned,
+		Payload: domain.MemberEvent{
+			ClubID:     ban.ClubID,
+			UserID:     ban.UserID,
+			ActorID:    ban.BannedBy,
+			OccurredAt: time.Now().UTC(),
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = promoteWaitlist(ctx, tx, ban.ClubID)
+	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("%s: no rows inserted into users_roles", op)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -118,25 +647,147 @@ func (s *Storage) AddNewMember(ctx context.Context, clubID, userID int64) error
 	return nil
 }
 
-func (s *Storage) DeleteJoinRequest(ctx context.Context, clubID, userID int64) error {
-	const op = "storage.postgresql.DeleteJoinRequest"
+func (s *Storage) ListBans(ctx context.Context, clubID int64, filters domain.Filters) ([]*domain.Ban, *domain.Metadata, error) {
+	const op = "storage.postgresql.ListBans"
 
-	query := `DELETE FROM join_club_requests 
-		WHERE club_id = $1 AND user_id = $2; 
+	query := `
+		SELECT count(*) OVER(), b.club_id, b.user_id, COALESCE(b.banned_by, 0), b.reason, b.expires_at, b.created_at,
+		       u.id, u.email, u.barcode, u.first_name, u.last_name, u.avatar_url
+		FROM club_bans b
+		JOIN users u ON u.id = b.user_id
+		WHERE b.club_id = $1 AND (b.expires_at IS NULL OR b.expires_at > CURRENT_TIMESTAMP)
+		ORDER BY b.created_at DESC
+		LIMIT $2 OFFSET $3;
 	`
-	result, err := s.DB.ExecContext(ctx, query, clubID, userID)
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, clubID, filters.Limit(), filters.Offset())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totalRecords int32
+	bans := []*domain.Ban{}
+
+	for rows.Next() {
+		var ban domain.Ban
+
+		err = rows.Scan(
+			&totalRecords, &ban.ClubID, &ban.UserID, &ban.BannedBy, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt,
+			&ban.User.ID, &ban.User.Email, &ban.User.Barcode, &ban.User.FirstName, &ban.User.LastName, &ban.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		bans = append(bans, &ban)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata := domain.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return bans, &metadata, nil
+}
+
+func (s *Storage) DeleteBan(ctx context.Context, clubID, userID int64, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.DeleteBan"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM club_bans WHERE club_id = $1 AND user_id = $2;`, clubID, userID)
 	if err != nil {
-		return fmt.Errorf("%s: failed to delete join request: %w", op, err)
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to delete ban: %w", op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: failed to get rows affected from delete: %w", op, err)
 	}
-
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: no rows updated", op)
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrBanNotExists)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
 	}
 
 	return nil
 }
+
+// GetClubMember returns the user together with their roles in the club.
+func (s *Storage) GetClubMember(ctx context.Context, clubID, userID int64) (*domain.User, error) {
+	const op = "storage.postgresql.GetClubMember"
+
+	query := `
+		SELECT u.id, u.email, u.barcode, u.first_name, u.last_name, u.avatar_url
+		FROM clubs_users cu
+		JOIN users u ON u.id = cu.user_id
+		WHERE cu.club_id = $1 AND cu.user_id = $2;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var user domain.User
+	err := s.DB.QueryRowContext(ctx, query, clubID, userID).Scan(
+		&user.ID, &user.Email, &user.Barcode, &user.FirstName, &user.LastName, &user.AvatarURL,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrUserNotClubMember)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rolesQuery := `
+		SELECT r.id, r.name, r.permissions, r.position, r.color, r.is_default
+		FROM users_roles ur
+		JOIN roles r ON ur.role_id = r.id
+		WHERE ur.user_id = $1 AND r.club_id = $2
+		ORDER BY r.position DESC;
+	`
+	rows, err := s.DB.QueryContext(ctx, rolesQuery, userID, clubID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: querying roles: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r domain.Role
+		err = rows.Scan(&r.ID, &r.Name, &r.Permissions.PermissionsHex, &r.Position, &r.Color, &r.IsDefault)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scanning roles: %w", op, err)
+		}
+		user.Roles = append(user.Roles, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterating roles: %w", op, err)
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,78 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"time"
+)
+
+// insertOutboxEvent writes the event to the outbox inside tx, so it is published only if tx commits.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, event domain.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.RoutingKey, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO outbox(routing_key, payload) VALUES ($1, $2);`, event.RoutingKey, string(payload))
+	if err != nil {
+		return fmt.Errorf("failed to insert %s event into outbox: %w", event.RoutingKey, err)
+	}
+
+	return nil
+}
+
+// FetchUnsentMessages returns up to limit unsent outbox messages, oldest first.
+func (s *Storage) FetchUnsentMessages(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	const op = "storage.postgresql.FetchUnsentMessages"
+
+	query := `
+		SELECT id, routing_key, payload, created_at
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		var (
+			msg     domain.OutboxMessage
+			payload []byte
+		)
+		err = rows.Scan(&msg.ID, &msg.RoutingKey, &payload, &msg.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		msg.Payload = payload
+
+		messages = append(messages, &msg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return messages, nil
+}
+
+func (s *Storage) MarkMessageSent(ctx context.Context, id int64) error {
+	const op = "storage.postgresql.MarkMessageSent"
+
+	_, err := s.DB.ExecContext(ctx, `UPDATE outbox SET sent_at = CURRENT_TIMESTAMP WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -33,7 +33,7 @@ func (s *Storage) GetUserRoles(ctx context.Context, clubID, userID int64) (roles
 	const op = "storage.postgresql.GetUserRoles"
 
 	query := `
-		SELECT c.owner_id = cu.user_id as is_owner, r.id, r.name, r.permissions, r.position, r.color
+		SELECT COALESCE(c.owner_id = cu.user_id, false) as is_owner, r.id, r.name, r.permissions, r.position, r.color, r.is_default
 		FROM clubs_users cu
 		LEFT JOIN clubs c ON c.id = cu.club_id
 		JOIN users_roles ur ON ur.user_id = cu.user_id
@@ -50,7 +50,7 @@ func (s *Storage) GetUserRoles(ctx context.Context, clubID, userID int64) (roles
 
 	for rows.Next() {
 		var role domain.Role
-		err = rows.Scan(&isOwner, &role.ID, &role.Name, &role.Permissions.PermissionsHex, &role.Position, &role.Color)
+		err = rows.Scan(&isOwner, &role.ID, &role.Name, &role.Permissions.PermissionsHex, &role.Position, &role.Color, &role.IsDefault)
 		if err != nil {
 			return nil, false, fmt.Errorf("%s: %w", op, err)
 		}
@@ -0,0 +1,588 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain/dtos"
+	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+func (s *Storage) GetRole(ctx context.Context, clubID int64, roleID int) (*domain.Role, error) {
+	const op = "storage.postgresql.GetRole"
+
+	query := `
+		SELECT id, name, permissions, position, color, is_default
+		FROM roles
+		WHERE club_id = $1 AND id = $2;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var role domain.Role
+	err := s.DB.QueryRowContext(ctx, query, clubID, roleID).Scan(
+		&role.ID, &role.Name, &role.Permissions.PermissionsHex, &role.Position, &role.Color, &role.IsDefault,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrRoleNotExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &role, nil
+}
+
+// CreateRole inserts a new role at dto.Position, roles at or above it are shifted up by one.
+// It returns storage.ErrRolePositionTooHigh unless dto.ActorID outranks the position.
+// The audit entry is recorded with the created role as its after payload.
+func (s *Storage) CreateRole(ctx context.Context, dto dtos.CreateRoleDTO, audit domain.AuditEntry) (*domain.Role, error) {
+	const op = "storage.postgresql.CreateRole"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	highest, err := lockRoleHierarchy(ctx, tx, dto.ClubID, dto.ActorID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if dto.Position >= highest {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRolePositionTooHigh)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE roles SET position = position + 1 WHERE club_id = $1 AND position >= $2;`, dto.ClubID, dto.Position)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to shift role positions: %w", op, err)
+	}
+
+	role := domain.Role{
+		Name:        dto.Name,
+		Permissions: domain.Permissions{PermissionsHex: dto.Permissions},
+		Position:    dto.Position,
+		Color:       dto.Color,
+	}
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO roles(club_id, name, permissions, position, color) VALUES ($1, $2, $3, $4, $5) RETURNING id;`,
+		dto.ClubID, dto.Name, strconv.FormatUint(dto.Permissions, 10), dto.Position, dto.Color,
+	).Scan(&role.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to insert role: %w", op, err)
+	}
+
+	audit.After = role.AuditPayload()
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return &role, nil
+}
+
+// UpdateRole applies the non-nil fields of dto to the role and returns the updated role.
+// It returns storage.ErrRolePositionTooHigh unless dto.ActorID outranks the role.
+// The audit entry is recorded with the updated role as its after payload.
+func (s *Storage) UpdateRole(ctx context.Context, dto dtos.UpdateRoleDTO, audit domain.AuditEntry) (*domain.Role, error) {
+	const op = "storage.postgresql.UpdateRole"
+
+	var permissions *string
+	if dto.Permissions != nil {
+		p := strconv.FormatUint(*dto.Permissions, 10)
+		permissions = &p
+	}
+
+	query := `
+		UPDATE roles
+		SET name = COALESCE($3, name),
+		    permissions = COALESCE($4, permissions),
+		    color = COALESCE($5, color)
+		WHERE club_id = $1 AND id = $2
+		RETURNING id, name, permissions, position, color, is_default;
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	highest, err := lockRoleHierarchy(ctx, tx, dto.ClubID, dto.ActorID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	position, err := rolePosition(ctx, tx, dto.ClubID, dto.RoleID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if position >= highest {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRolePositionTooHigh)
+	}
+
+	var role domain.Role
+	err = tx.QueryRowContext(ctx, query, dto.ClubID, dto.RoleID, dto.Name, permissions, dto.Color).Scan(
+		&role.ID, &role.Name, &role.Permissions.PermissionsHex, &role.Position, &role.Color, &role.IsDefault,
+	)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrRoleNotExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	audit.After = role.AuditPayload()
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return &role, nil
+}
+
+// MoveRole moves the role to position and shifts the roles in between to keep the order.
+// It returns storage.ErrRolePositionTooHigh unless actorID outranks both the old and the new position.
+func (s *Storage) MoveRole(ctx context.Context, clubID, actorID int64, roleID int, position int, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.MoveRole"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	highest, err := lockRoleHierarchy(ctx, tx, clubID, actorID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var current int
+	err = tx.QueryRowContext(ctx, `SELECT position FROM roles WHERE club_id = $1 AND id = $2 FOR UPDATE;`, clubID, roleID).Scan(&current)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrRoleNotExists)
+		}
+		return fmt.Errorf("%s: failed to get role position: %w", op, err)
+	}
+	if max(current, position) >= highest {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrRolePositionTooHigh)
+	}
+
+	switch {
+	case position < current:
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE roles SET position = position + 1 WHERE club_id = $1 AND position >= $2 AND position < $3;`,
+			clubID, position, current,
+		)
+	case position > current:
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE roles SET position = position - 1 WHERE club_id = $1 AND position > $2 AND position <= $3;`,
+			clubID, current, position,
+		)
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to shift role positions: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE roles SET position = $3 WHERE club_id = $1 AND id = $2;`, clubID, roleID, position)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to update role position: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteRole removes the role from every member and deletes it, roles above it are shifted down by one.
+// It returns storage.ErrRolePositionTooHigh unless actorID outranks the role.
+func (s *Storage) DeleteRole(ctx context.Context, clubID, actorID int64, roleID int, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.DeleteRole"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	highest, err := lockRoleHierarchy(ctx, tx, clubID, actorID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var position int
+	err = tx.QueryRowContext(ctx, `SELECT position FROM roles WHERE club_id = $1 AND id = $2 FOR UPDATE;`, clubID, roleID).Scan(&position)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrRoleNotExists)
+		}
+		return fmt.Errorf("%s: failed to get role position: %w", op, err)
+	}
+	if position >= highest {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrRolePositionTooHigh)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users_roles WHERE role_id = $1;`, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to delete from users_roles: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM roles WHERE club_id = $1 AND id = $2;`, clubID, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to delete role: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE roles SET position = position - 1 WHERE club_id = $1 AND position > $2;`, clubID, position)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to shift role positions: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// AssignRole gives the role of the club to the user. It returns storage.ErrRolePositionTooHigh unless
+// actorID outranks the role and is not outranked by the user.
+func (s *Storage) AssignRole(ctx context.Context, clubID, actorID, userID int64, roleID int, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.AssignRole"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = checkRoleChange(ctx, tx, clubID, actorID, userID, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO users_roles(user_id, role_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, role_id) DO NOTHING;
+	`
+	_, err = tx.ExecContext(ctx, query, userID, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to insert to users_roles: %w", op, err)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeRole takes the role of the club away from the user. It returns storage.ErrRolePositionTooHigh unless
+// actorID outranks the role and is not outranked by the user.
+func (s *Storage) RevokeRole(ctx context.Context, clubID, actorID, userID int64, roleID int, audit domain.AuditEntry) error {
+	const op = "storage.postgresql.RevokeRole"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = checkRoleChange(ctx, tx, clubID, actorID, userID, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users_roles WHERE user_id = $1 AND role_id = $2;`, userID, roleID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to delete from users_roles: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to get rows affected from delete: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrRoleNotAssigned)
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// handOverOwnerRoles moves the owner roles of the club from the previous owner to the new owner within tx
+// and audits every revoked and assigned role, actorID is zero if the handover is not done by a user.
+func handOverOwnerRoles(ctx context.Context, tx *sql.Tx, clubID, fromUserID, toUserID, actorID int64) error {
+	revoked, err := queryRoles(ctx, tx, `
+		DELETE FROM users_roles ur
+		USING roles r
+		WHERE ur.role_id = r.id AND r.club_id = $1 AND r.is_owner_role AND ur.user_id = $2
+		RETURNING r.id, r.name, r.permissions, r.position, r.color;
+	`, clubID, fromUserID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke owner roles: %w", err)
+	}
+
+	assigned, err := queryRoles(ctx, tx, `
+		WITH assigned AS (
+			INSERT INTO users_roles(user_id, role_id)
+			SELECT $2, id FROM roles WHERE club_id = $1 AND is_owner_role
+			ON CONFLICT (user_id, role_id) DO NOTHING
+			RETURNING role_id
+		)
+		SELECT r.id, r.name, r.permissions, r.position, r.color
+		FROM assigned a
+		JOIN roles r ON r.id = a.role_id;
+	`, clubID, toUserID)
+	if err != nil {
+		return fmt.Errorf("failed to assign owner roles: %w", err)
+	}
+
+	for _, entry := range domain.OwnerRoleHandover(clubID, actorID, fromUserID, toUserID, revoked, assigned) {
+		err = insertAuditEntry(ctx, tx, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryRoles returns the roles selected by query, ordered from the highest position.
+func queryRoles(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]domain.Role, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		var role domain.Role
+		err = rows.Scan(&role.ID, &role.Name, &role.Permissions.PermissionsHex, &role.Position, &role.Color)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(roles, func(a, b domain.Role) int { return b.Position - a.Position })
+
+	return roles, nil
+}
+
+// lockRoleHierarchy locks the club row, so that role positions of the club can not change
+// until the transaction ends, and returns the rank of the actor.
+func lockRoleHierarchy(ctx context.Context, tx *sql.Tx, clubID, actorID int64) (int, error) {
+	ownerID, err := lockClubOwner(ctx, tx, clubID)
+	if err != nil {
+		return 0, err
+	}
+
+	return memberRank(ctx, tx, clubID, ownerID, actorID)
+}
+
+// lockClubOwner locks the club row until the transaction ends and returns the owner of the club.
+func lockClubOwner(ctx context.Context, tx *sql.Tx, clubID int64) (int64, error) {
+	var ownerID int64
+	err := tx.QueryRowContext(
+		ctx, `SELECT COALESCE(owner_id, 0) FROM clubs WHERE id = $1 FOR NO KEY UPDATE;`, clubID,
+	).Scan(&ownerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrClubNotExists
+		}
+		return 0, fmt.Errorf("failed to lock club: %w", err)
+	}
+
+	return ownerID, nil
+}
+
+// memberRank returns the highest role position of the user in the club. The owner outranks
+// every role, a user without roles outranks none.
+func memberRank(ctx context.Context, tx *sql.Tx, clubID, ownerID, userID int64) (int, error) {
+	if ownerID == userID {
+		return math.MaxInt, nil
+	}
+
+	var highest int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(r.position), -1)
+		FROM users_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE r.club_id = $1 AND ur.user_id = $2;
+	`, clubID, userID).Scan(&highest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get highest role position: %w", err)
+	}
+
+	return highest, nil
+}
+
+// rolePosition returns the position of the role of the club, callers hold the hierarchy lock.
+func rolePosition(ctx context.Context, tx *sql.Tx, clubID int64, roleID int) (int, error) {
+	var position int
+	err := tx.QueryRowContext(ctx, `SELECT position FROM roles WHERE club_id = $1 AND id = $2;`, clubID, roleID).Scan(&position)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrRoleNotExists
+		}
+		return 0, fmt.Errorf("failed to get role position: %w", err)
+	}
+
+	return position, nil
+}
+
+// checkRoleChange locks the role hierarchy of the club and checks that actorID outranks the role
+// and is not outranked by the user whose roles change.
+func checkRoleChange(ctx context.Context, tx *sql.Tx, clubID, actorID, userID int64, roleID int) error {
+	ownerID, err := lockClubOwner(ctx, tx, clubID)
+	if err != nil {
+		return err
+	}
+
+	highest, err := memberRank(ctx, tx, clubID, ownerID, actorID)
+	if err != nil {
+		return err
+	}
+
+	position, err := rolePosition(ctx, tx, clubID, roleID)
+	if err != nil {
+		return err
+	}
+	if position >= highest {
+		return storage.ErrRolePositionTooHigh
+	}
+
+	target, err := memberRank(ctx, tx, clubID, ownerID, userID)
+	if err != nil {
+		return err
+	}
+	if target > highest {
+		return storage.ErrRolePositionTooHigh
+	}
+
+	return nil
+}
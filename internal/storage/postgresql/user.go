@@ -8,22 +8,38 @@ import (
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-club-service/internal/storage"
 	"github.com/jackc/pgx/v5/pgconn"
+	"time"
 )
 
-func (s *Storage) SaveUser(ctx context.Context, user *domain.User) error {
+// SaveUser inserts the user, a user with the same id is left untouched so that redelivered
+// events are no-ops. Email or barcode of another user returns storage.ErrUserConflict.
+func (s *Storage) SaveUser(ctx context.Context, user *domain.User, meta domain.MessageMeta) error {
 	const op = "storage.postgresql.SaveUser"
 
-	stmt, err := s.DB.Prepare(`
-		INSERT INTO users(id, email, barcode, first_name, last_name, avatar_url)
-		values($1, $2, $3, $4, $5, $6)
-		returning id;
-	`)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
 	}
 
-	defer stmt.Close()
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
 
+	err = markMessageProcessed(ctx, tx, meta.ID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO users(id, email, barcode, first_name, last_name, avatar_url, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING;
+	`
 	args := []any{
 		user.ID,
 		user.Email,
@@ -31,21 +47,25 @@ func (s *Storage) SaveUser(ctx context.Context, user *domain.User) error {
 		user.FirstName,
 		user.LastName,
 		user.AvatarURL,
+		meta.Version,
 	}
 
-	result := stmt.QueryRowContext(ctx, args...)
-
-	err = result.Scan(&user.ID)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
+		tx.Rollback()
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
-				return fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+				return fmt.Errorf("%s: %w", op, storage.ErrUserConflict)
 			}
 		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -81,19 +101,36 @@ func (s *Storage) GetUserByID(ctx context.Context, userID int64) (*domain.User,
 	return &user, nil
 }
 
-func (s *Storage) UpdateUser(ctx context.Context, user *domain.User) error {
+// UpdateUser updates the user unless the stored version is newer than meta.Version,
+// in that case storage.ErrStaleEvent is returned.
+func (s *Storage) UpdateUser(ctx context.Context, user *domain.User, meta domain.MessageMeta) error {
 	const op = "storage.postgresql.UpdateUser"
 
-	stmt, err := s.DB.Prepare(`
-		UPDATE users
-		SET email = $2,barcode = $3, first_name = $4, last_name = $5 ,avatar_url = $6
-		WHERE id = $1;
-	`)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = markMessageProcessed(ctx, tx, meta.ID)
+	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
 
+	query := `
+		UPDATE users
+		SET email = $2, barcode = $3, first_name = $4, last_name = $5, avatar_url = $6,
+		    version = GREATEST(version, $7)
+		WHERE id = $1 AND ($7 = 0 OR version <= $7);
+	`
 	args := []any{
 		user.ID,
 		user.Email,
@@ -101,44 +138,411 @@ func (s *Storage) UpdateUser(ctx context.Context, user *domain.User) error {
 		user.FirstName,
 		user.LastName,
 		user.AvatarURL,
+		meta.Version,
 	}
-	result, err := stmt.ExecContext(ctx, args...)
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
+		tx.Rollback()
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, storage.ErrUserConflict)
+			}
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, storage.ErrUserNotExists)
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, s.missingOrStale(ctx, user.ID))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) DeleteUserByID(ctx context.Context, userID int64) error {
+// DeleteUserByID deletes the user together with their memberships, roles, join requests and bans.
+// Each club the user owns is handed over with its owner roles to the member picked by domain.PickSuccessor, clubs
+// without other members are left without owner and deactivated, pending ones are rejected.
+// storage.ErrStaleEvent is returned if the stored version is newer than meta.Version.
+func (s *Storage) DeleteUserByID(ctx context.Context, userID int64, meta domain.MessageMeta) error {
 	const op = "storage.postgresql.DeleteUserByID"
 
-	stmt, err := s.DB.Prepare(`DELETE FROM users WHERE id = $1;`)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = markMessageProcessed(ctx, tx, meta.ID)
+	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, userID)
+	var version int64
+	err = tx.QueryRowContext(ctx, `SELECT version FROM users WHERE id = $1 FOR UPDATE;`, userID).Scan(&version)
 	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotExists)
+		}
+		return fmt.Errorf("%s: failed to get user version: %w", op, err)
+	}
+	if meta.Version != 0 && version > meta.Version {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, storage.ErrStaleEvent)
+	}
+
+	event := domain.UserRemovedEvent{UserID: userID, OccurredAt: time.Now().UTC()}
+
+	clubIDs, err := lockClubIDs(ctx, tx, `SELECT id FROM clubs WHERE owner_id = $1 ORDER BY id FOR UPDATE;`, userID)
+	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	for _, clubID := range clubIDs {
+		candidates, err := successorCandidates(ctx, tx, clubID, userID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to get successor of club %d: %w", op, clubID, err)
+		}
+
+		successorID, ok := domain.PickSuccessor(candidates)
+		if !ok {
+			err = closeAbandonedClub(ctx, tx, clubID, userID)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			event.ClosedClubs = append(event.ClosedClubs, clubID)
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE clubs SET owner_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`, clubID, successorID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to update owner of club %d: %w", op, clubID, err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO club_ownership_transfers(club_id, from_user_id, to_user_id) VALUES ($1, $2, $3);`,
+			clubID, userID, successorID,
+		)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to insert ownership transfer of club %d: %w", op, clubID, err)
+		}
+
+		err = handOverOwnerRoles(ctx, tx, clubID, userID, successorID, 0)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to hand over owner roles of club %d: %w", op, clubID, err)
+		}
+
+		err = insertAuditEntry(ctx, tx, domain.AuditEntry{
+			ClubID:   clubID,
+			TargetID: successorID,
+			Action:   domain.AuditOwnershipTransferred,
+			Before:   map[string]int64{"owner_id": userID},
+			After:    map[string]int64{"owner_id": successorID},
+		})
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		event.TransferredClubs = append(event.TransferredClubs, domain.OwnershipHandover{ClubID: clubID, NewOwnerID: successorID})
+	}
+
+	// Seats freed in active clubs go to their waitlists once the memberships are deleted.
+	memberClubIDsQuery := `
+		SELECT c.id FROM clubs c
+		JOIN clubs_users cu ON cu.club_id = c.id
+		WHERE cu.user_id = $1 AND c.status = 'active'
+		ORDER BY c.id
+		FOR NO KEY UPDATE OF c;
+	`
+	memberClubIDs, err := lockClubIDs(ctx, tx, memberClubIDsQuery, userID)
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("%s: %w", op, err)
 	}
+
+	// Club applications are kept for the history, their user_id is nulled by the foreign key.
+	cleanupQueries := []string{
+		`DELETE FROM users_roles WHERE user_id = $1;`,
+		`DELETE FROM clubs_users WHERE user_id = $1;`,
+		`DELETE FROM join_club_requests WHERE user_id = $1;`,
+		`DELETE FROM club_bans WHERE user_id = $1;`,
+		`DELETE FROM users WHERE id = $1;`,
+	}
+	for _, query := range cleanupQueries {
+		_, err = tx.ExecContext(ctx, query, userID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: failed to execute %q: %w", op, query, err)
+		}
+	}
+
+	for _, clubID := range memberClubIDs {
+		err = promoteWaitlist(ctx, tx, clubID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.Event{RoutingKey: domain.EventUserRemoved, Payload: event})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return nil
+}
+
+// successorCandidates returns the members of the club other than ownerID with their highest role position.
+func successorCandidates(ctx context.Context, tx *sql.Tx, clubID, ownerID int64) ([]domain.SuccessorCandidate, error) {
+	query := `
+		SELECT cu.user_id, COALESCE(MAX(r.position), -1), cu.joined_at
+		FROM clubs_users cu
+		LEFT JOIN users_roles ur ON ur.user_id = cu.user_id
+		LEFT JOIN roles r ON r.id = ur.role_id AND r.club_id = cu.club_id
+		WHERE cu.club_id = $1 AND cu.user_id <> $2
+		GROUP BY cu.user_id, cu.joined_at;
+	`
+	rows, err := tx.QueryContext(ctx, query, clubID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []domain.SuccessorCandidate
+	for rows.Next() {
+		var c domain.SuccessorCandidate
+		if err = rows.Scan(&c.UserID, &c.Position, &c.JoinedAt); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// closeAbandonedClub clears the owner of the locked club, moves it to its abandoned status
+// and rejects its pending application, the change is audited without an actor.
+func closeAbandonedClub(ctx context.Context, tx *sql.Tx, clubID, ownerID int64) error {
+	var status domain.ClubStatus
+	err := tx.QueryRowContext(ctx, `SELECT status FROM clubs WHERE id = $1;`, clubID).Scan(&status)
+	if err != nil {
+		return fmt.Errorf("failed to get status of club %d: %w", clubID, err)
+	}
+	next := status.Abandoned()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE clubs SET owner_id = NULL, status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`,
+		clubID, next,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close club %d: %w", clubID, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE create_club_requests SET status = $2, reason = $3, decided_at = CURRENT_TIMESTAMP WHERE club_id = $1 AND status = 'pending';`,
+		clubID, domain.ApplicationStatusRejected, "applicant was deleted",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reject application of club %d: %w", clubID, err)
+	}
+
+	action := domain.AuditClubDeactivated
+	if next == domain.ClubStatusRejected {
+		action = domain.AuditClubRejected
+	}
+	return insertAuditEntry(ctx, tx, domain.AuditEntry{
+		ClubID: clubID,
+		Action: action,
+		Before: map[string]any{"status": status, "owner_id": ownerID},
+		After:  map[string]any{"status": next, "owner_id": nil},
+	})
+}
+
+// lockClubIDs returns the ids of clubs selected by query for the user, query is expected to lock them.
+func lockClubIDs(ctx context.Context, tx *sql.Tx, query string, userID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clubs: %w", err)
+	}
+	defer rows.Close()
+
+	var clubIDs []int64
+	for rows.Next() {
+		var clubID int64
+		if err = rows.Scan(&clubID); err != nil {
+			return nil, fmt.Errorf("failed to scan club: %w", err)
+		}
+		clubIDs = append(clubIDs, clubID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get clubs: %w", err)
+	}
+
+	return clubIDs, nil
+}
+
+// RequestUserResync writes a resync request to the outbox for every other user holding
+// the email or barcode of user and returns their ids.
+func (s *Storage) RequestUserResync(ctx context.Context, user *domain.User) ([]int64, error) {
+	const op = "storage.postgresql.RequestUserResync"
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Defer the rollback in case of any error.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT id FROM users WHERE id <> $1 AND (email = $2 OR barcode = $3) ORDER BY id;`,
+		user.ID, user.Email, user.Barcode,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: failed to get conflicting users: %w", op, err)
+	}
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err = rows.Scan(&userID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, userID := range userIDs {
+		err = insertOutboxEvent(ctx, tx, domain.Event{
+			RoutingKey: domain.EventUserResyncRequested,
+			Payload: domain.UserResyncEvent{
+				UserID:            userID,
+				ConflictingUserID: user.ID,
+				OccurredAt:        time.Now().UTC(),
+			},
+		})
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: transaction commit failed: %w", op, err)
+	}
+
+	return userIDs, nil
+}
+
+// markMessageProcessed records messageID in tx, storage.ErrMessageProcessed is returned
+// if the message was already handled. Messages without id are not tracked.
+func markMessageProcessed(ctx context.Context, tx *sql.Tx, messageID string) error {
+	if messageID == "" {
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO processed_messages(message_id) VALUES ($1) ON CONFLICT DO NOTHING;`, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to insert into processed_messages: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected from insert: %w", err)
+	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, storage.ErrUserNotExists)
+		return storage.ErrMessageProcessed
 	}
 
 	return nil
 }
+
+// DeleteProcessedMessages forgets up to limit processed message IDs recorded before the given time
+// and returns how many were deleted.
+func (s *Storage) DeleteProcessedMessages(ctx context.Context, before time.Time, limit int) (int64, error) {
+	const op = "storage.postgresql.DeleteProcessedMessages"
+
+	query := `
+		DELETE FROM processed_messages
+		WHERE message_id IN (
+			SELECT message_id FROM processed_messages
+			WHERE processed_at < $1
+			LIMIT $2
+		);
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := s.DB.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get rows affected from delete: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+// missingOrStale tells why a versioned write to the user did not affect any row.
+func (s *Storage) missingOrStale(ctx context.Context, userID int64) error {
+	var exists bool
+	err := s.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1);`, userID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return storage.ErrStaleEvent
+	}
+	return storage.ErrUserNotExists
+}
@@ -3,8 +3,28 @@ package storage
 import "errors"
 
 var (
-	ErrUserExists        = errors.New("user already exists")
-	ErrUserNotExists     = errors.New("user does not exists")
-	ErrClubNotExists     = errors.New("club does not exists")
-	ErrUserNotClubMember = errors.New("user is not club member")
+	ErrUserExists           = errors.New("user already exists")
+	ErrUserConflict         = errors.New("email or barcode belongs to another user")
+	ErrUserNotExists        = errors.New("user does not exists")
+	ErrClubNotExists        = errors.New("club does not exists")
+	ErrUserNotClubMember    = errors.New("user is not club member")
+	ErrClubNotActive        = errors.New("club is not active")
+	ErrClubStatusChanged    = errors.New("club status has changed")
+	ErrUserIsClubOwner      = errors.New("user is club owner")
+	ErrUserBanned           = errors.New("user is banned from club")
+	ErrBanNotExists         = errors.New("ban does not exists")
+	ErrUserIsClubMember     = errors.New("user is already club member")
+	ErrJoinRequestExists    = errors.New("pending join request already exists")
+	ErrJoinRequestNotExists = errors.New("pending join request does not exists")
+	ErrClubInviteOnly       = errors.New("club accepts members by invitation only")
+	ErrClubClosed           = errors.New("club does not accept new members")
+	ErrClubFull             = errors.New("club has reached max members")
+	ErrInvitationNotExists  = errors.New("invitation does not exists")
+	ErrInvitationNotValid   = errors.New("invitation is revoked, expired or used up")
+	ErrRoleNotExists        = errors.New("role does not exists")
+	ErrRoleNotAssigned      = errors.New("role is not assigned to user")
+	ErrRolePositionTooHigh  = errors.New("role position is not below the highest role of the user")
+	ErrClubOwnerChanged     = errors.New("club owner has changed")
+	ErrMessageProcessed     = errors.New("message already processed")
+	ErrStaleEvent           = errors.New("event is older than stored state")
 )
@@ -0,0 +1,5 @@
+ALTER TABLE clubs ADD COLUMN approved BOOLEAN DEFAULT false NOT NULL;
+
+UPDATE clubs SET approved = true WHERE status IN ('active', 'deactivated');
+
+ALTER TABLE clubs DROP COLUMN status;
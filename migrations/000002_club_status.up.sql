@@ -0,0 +1,7 @@
+ALTER TABLE clubs
+    ADD COLUMN status TEXT DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'active', 'deactivated', 'rejected'));
+
+UPDATE clubs SET status = 'active' WHERE approved;
+
+ALTER TABLE clubs DROP COLUMN approved;
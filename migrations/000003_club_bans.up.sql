@@ -0,0 +1,9 @@
+CREATE TABLE club_bans (
+    club_id BIGINT NOT NULL REFERENCES clubs(id),
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    banned_by BIGINT NOT NULL REFERENCES users(id),
+    reason TEXT DEFAULT '' NOT NULL,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (club_id, user_id)
+);
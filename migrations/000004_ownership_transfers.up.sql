@@ -0,0 +1,10 @@
+CREATE TABLE club_ownership_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    club_id BIGINT NOT NULL REFERENCES clubs(id),
+    from_user_id BIGINT NOT NULL REFERENCES users(id),
+    to_user_id BIGINT NOT NULL REFERENCES users(id),
+    transferred_by BIGINT NOT NULL REFERENCES users(id),
+    transferred_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX club_ownership_transfers_club_id_idx ON club_ownership_transfers(club_id);
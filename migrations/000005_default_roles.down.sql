@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS roles_club_id_is_default_idx;
+
+ALTER TABLE roles DROP COLUMN is_default;
@@ -0,0 +1,5 @@
+ALTER TABLE roles ADD COLUMN is_default BOOLEAN DEFAULT false NOT NULL;
+
+UPDATE roles SET is_default = true WHERE name = 'member';
+
+CREATE UNIQUE INDEX roles_club_id_is_default_idx ON roles(club_id) WHERE is_default;
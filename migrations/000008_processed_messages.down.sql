@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+
+DROP TABLE IF EXISTS processed_messages;
@@ -0,0 +1,6 @@
+CREATE TABLE processed_messages (
+    message_id TEXT PRIMARY KEY,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+ALTER TABLE users ADD COLUMN version BIGINT DEFAULT 0 NOT NULL;
@@ -0,0 +1,21 @@
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_from_user_id_fkey;
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_to_user_id_fkey;
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_transferred_by_fkey;
+DELETE FROM club_ownership_transfers WHERE from_user_id IS NULL OR to_user_id IS NULL OR transferred_by IS NULL;
+ALTER TABLE club_ownership_transfers ALTER COLUMN from_user_id SET NOT NULL;
+ALTER TABLE club_ownership_transfers ALTER COLUMN to_user_id SET NOT NULL;
+ALTER TABLE club_ownership_transfers ALTER COLUMN transferred_by SET NOT NULL;
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_from_user_id_fkey
+    FOREIGN KEY (from_user_id) REFERENCES users(id);
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_to_user_id_fkey
+    FOREIGN KEY (to_user_id) REFERENCES users(id);
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_transferred_by_fkey
+    FOREIGN KEY (transferred_by) REFERENCES users(id);
+
+ALTER TABLE club_bans DROP CONSTRAINT club_bans_banned_by_fkey;
+DELETE FROM club_bans WHERE banned_by IS NULL;
+ALTER TABLE club_bans ALTER COLUMN banned_by SET NOT NULL;
+ALTER TABLE club_bans ADD CONSTRAINT club_bans_banned_by_fkey FOREIGN KEY (banned_by) REFERENCES users(id);
+
+-- Fails while clubs without owner exist, they have to be handed over first.
+ALTER TABLE clubs ALTER COLUMN owner_id SET NOT NULL;
@@ -0,0 +1,21 @@
+-- Clubs of a deleted user that could not be handed over keep no owner.
+ALTER TABLE clubs ALTER COLUMN owner_id DROP NOT NULL;
+
+-- History keeps the rows of deleted users with NULL references.
+ALTER TABLE club_bans ALTER COLUMN banned_by DROP NOT NULL;
+ALTER TABLE club_bans DROP CONSTRAINT club_bans_banned_by_fkey;
+ALTER TABLE club_bans ADD CONSTRAINT club_bans_banned_by_fkey
+    FOREIGN KEY (banned_by) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE club_ownership_transfers ALTER COLUMN from_user_id DROP NOT NULL;
+ALTER TABLE club_ownership_transfers ALTER COLUMN to_user_id DROP NOT NULL;
+ALTER TABLE club_ownership_transfers ALTER COLUMN transferred_by DROP NOT NULL;
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_from_user_id_fkey;
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_to_user_id_fkey;
+ALTER TABLE club_ownership_transfers DROP CONSTRAINT club_ownership_transfers_transferred_by_fkey;
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_from_user_id_fkey
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_to_user_id_fkey
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE club_ownership_transfers ADD CONSTRAINT club_ownership_transfers_transferred_by_fkey
+    FOREIGN KEY (transferred_by) REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS create_club_requests_user_id_idx;
+DROP INDEX IF EXISTS create_club_requests_pending_idx;
+
+DELETE FROM create_club_requests WHERE status <> 'pending';
+
+ALTER TABLE create_club_requests
+    DROP COLUMN decided_at,
+    DROP COLUMN reason,
+    DROP COLUMN reviewer_id,
+    DROP COLUMN status;
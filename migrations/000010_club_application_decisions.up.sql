@@ -0,0 +1,8 @@
+ALTER TABLE create_club_requests
+    ADD COLUMN status TEXT DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN reviewer_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN reason TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN decided_at TIMESTAMP;
+
+CREATE UNIQUE INDEX create_club_requests_pending_idx ON create_club_requests(club_id) WHERE status = 'pending';
+CREATE INDEX create_club_requests_user_id_idx ON create_club_requests(user_id, request_time DESC);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS join_club_requests_user_id_idx;
+DROP INDEX IF EXISTS join_club_requests_pending_idx;
+
+DELETE FROM join_club_requests WHERE status <> 'pending';
+
+ALTER TABLE join_club_requests
+    DROP COLUMN decided_at,
+    DROP COLUMN note,
+    DROP COLUMN reviewer_id,
+    DROP COLUMN message,
+    DROP COLUMN status;
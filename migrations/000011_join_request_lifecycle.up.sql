@@ -0,0 +1,18 @@
+-- Keep only the earliest request per user and club, and drop requests of users who are already members.
+DELETE FROM join_club_requests jcr
+USING join_club_requests earlier
+WHERE jcr.club_id = earlier.club_id AND jcr.user_id = earlier.user_id AND jcr.id > earlier.id;
+
+DELETE FROM join_club_requests jcr
+USING clubs_users cu
+WHERE cu.club_id = jcr.club_id AND cu.user_id = jcr.user_id;
+
+ALTER TABLE join_club_requests
+    ADD COLUMN status TEXT DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'withdrawn')),
+    ADD COLUMN message TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN reviewer_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN note TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN decided_at TIMESTAMP;
+
+CREATE UNIQUE INDEX join_club_requests_pending_idx ON join_club_requests(club_id, user_id) WHERE status = 'pending';
+CREATE INDEX join_club_requests_user_id_idx ON join_club_requests(user_id, request_time DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE clubs
+    ADD COLUMN join_policy TEXT DEFAULT 'approval' NOT NULL CHECK (join_policy IN ('open', 'approval', 'invite_only', 'closed'));
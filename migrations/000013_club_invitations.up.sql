@@ -0,0 +1,14 @@
+CREATE TABLE club_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    club_id BIGINT NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    code TEXT NOT NULL UNIQUE,
+    invitee_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    max_uses INT CHECK (max_uses > 0),
+    uses INT DEFAULT 0 NOT NULL,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX club_invitations_club_id_idx ON club_invitations(club_id, created_at DESC);
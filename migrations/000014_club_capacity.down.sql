@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS join_club_requests_waitlist_idx;
+DROP INDEX IF EXISTS join_club_requests_open_idx;
+
+UPDATE join_club_requests SET status = 'pending', decided_at = NULL WHERE status = 'waitlisted';
+
+ALTER TABLE join_club_requests DROP CONSTRAINT join_club_requests_status_check;
+ALTER TABLE join_club_requests ADD CONSTRAINT join_club_requests_status_check
+    CHECK (status IN ('pending', 'approved', 'rejected', 'withdrawn'));
+
+CREATE UNIQUE INDEX join_club_requests_pending_idx ON join_club_requests(club_id, user_id) WHERE status = 'pending';
+
+ALTER TABLE clubs DROP COLUMN max_members;
@@ -0,0 +1,12 @@
+ALTER TABLE clubs ADD COLUMN max_members INT CHECK (max_members > 0);
+
+-- Approved requests of full clubs wait for a free seat.
+ALTER TABLE join_club_requests DROP CONSTRAINT join_club_requests_status_check;
+ALTER TABLE join_club_requests ADD CONSTRAINT join_club_requests_status_check
+    CHECK (status IN ('pending', 'waitlisted', 'approved', 'rejected', 'withdrawn'));
+
+DROP INDEX IF EXISTS join_club_requests_pending_idx;
+CREATE UNIQUE INDEX join_club_requests_open_idx ON join_club_requests(club_id, user_id)
+    WHERE status IN ('pending', 'waitlisted');
+CREATE INDEX join_club_requests_waitlist_idx ON join_club_requests(club_id, request_time)
+    WHERE status IN ('pending', 'waitlisted');
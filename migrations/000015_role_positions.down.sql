@@ -0,0 +1 @@
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_club_id_position_key;
@@ -0,0 +1,13 @@
+-- Renumber clubs that already have duplicate positions, keeping the current order.
+UPDATE roles r
+SET position = ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY club_id ORDER BY position, id) - 1 AS position
+    FROM roles
+    WHERE club_id IN (SELECT club_id FROM roles GROUP BY club_id, position HAVING count(*) > 1)
+) ranked
+WHERE r.id = ranked.id;
+
+-- Checked at commit, so that positions can be shifted by one within a transaction.
+ALTER TABLE roles ADD CONSTRAINT roles_club_id_position_key UNIQUE (club_id, position)
+    DEFERRABLE INITIALLY DEFERRED;
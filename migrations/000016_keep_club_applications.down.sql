@@ -0,0 +1,6 @@
+DELETE FROM create_club_requests WHERE user_id IS NULL;
+
+ALTER TABLE create_club_requests
+    DROP CONSTRAINT create_club_requests_user_id_fkey,
+    ADD CONSTRAINT create_club_requests_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+    ALTER COLUMN user_id SET NOT NULL;
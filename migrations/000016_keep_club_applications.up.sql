@@ -0,0 +1,4 @@
+ALTER TABLE create_club_requests
+    ALTER COLUMN user_id DROP NOT NULL,
+    DROP CONSTRAINT create_club_requests_user_id_fkey,
+    ADD CONSTRAINT create_club_requests_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
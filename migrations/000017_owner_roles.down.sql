@@ -0,0 +1 @@
+ALTER TABLE roles DROP COLUMN is_owner_role;
@@ -0,0 +1,7 @@
+ALTER TABLE roles ADD COLUMN is_owner_role BOOLEAN DEFAULT false NOT NULL;
+
+-- Roles seeded for the owner were not marked before, take the non-default roles the current owner holds.
+UPDATE roles r
+SET is_owner_role = true
+FROM clubs c, users_roles ur
+WHERE c.id = r.club_id AND ur.role_id = r.id AND ur.user_id = c.owner_id AND NOT r.is_default;
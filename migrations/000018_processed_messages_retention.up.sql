@@ -0,0 +1,2 @@
+-- Processed message IDs are deleted once they are older than the retention period.
+CREATE INDEX processed_messages_processed_at_idx ON processed_messages(processed_at);